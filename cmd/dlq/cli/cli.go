@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"productfc/cmd/dlq/service"
+	"productfc/models"
+	"strings"
+	"text/tabwriter"
+)
+
+const usage = `usage: productfc dlq <command> [flags]
+
+commands:
+  list     DLQ 메시지 목록 (-topic, -original-topic, -order, -error, -class, -status, -page, -limit)
+  show     DLQ 메시지 상세 (-id)
+  replay   원본 토픽으로 재발행 (-id, -body-file: 수정 본문 JSON 파일)
+  resolve  해결됨으로 표시 (-id, -note)
+  stats    DLQ 토픽/상태별 건수
+`
+
+// Run — "productfc dlq ..." 서브커맨드 실행. 종료 코드 반환.
+func Run(ctx context.Context, dlqService *service.DLQService, args []string, out io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(out, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "list":
+		err = list(ctx, dlqService, args[1:], out)
+	case "show":
+		err = show(ctx, dlqService, args[1:], out)
+	case "replay":
+		err = replay(ctx, dlqService, args[1:], out)
+	case "resolve":
+		err = resolve(ctx, dlqService, args[1:], out)
+	case "stats":
+		err = stats(ctx, dlqService, out)
+	default:
+		fmt.Fprintf(out, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(out, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func list(ctx context.Context, dlqService *service.DLQService, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(out)
+	var params models.DLQSearchParameter
+	fs.StringVar(&params.DLQTopic, "topic", "", "DLQ topic (e.g. stock.updated.dlq)")
+	fs.StringVar(&params.OriginalTopic, "original-topic", "", "original topic")
+	fs.Int64Var(&params.OrderID, "order", 0, "order ID")
+	fs.StringVar(&params.ErrorContains, "error", "", "error text contains")
+	fs.StringVar(&params.ErrorClass, "class", "", "processing | decode | schema")
+	fs.StringVar(&params.Status, "status", "", "pending | replayed | resolved")
+	fs.IntVar(&params.Page, "page", 1, "page number")
+	fs.IntVar(&params.PageSize, "limit", 50, "page size")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 50
+	}
+
+	messages, totalCount, err := dlqService.SearchMessages(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDLQ_TOPIC\tORIGINAL_TOPIC\tORDER_ID\tCLASS\tATTEMPTS\tSTATUS\tREPLAYS\tCREATED_AT\tERROR")
+	for _, m := range messages {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%d\t%s\t%d\t%s\t%s\n",
+			m.ID, m.DLQTopic, m.OriginalTopic, m.OrderID, m.ErrorClass, m.Attempts, m.Status, m.ReplayCount,
+			m.CreatedAt.Format("2006-01-02 15:04:05"), truncate(m.Error, 80))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n%d of %d message(s)\n", len(messages), totalCount)
+	return nil
+}
+
+func show(ctx context.Context, dlqService *service.DLQService, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	fs.SetOutput(out)
+	id := fs.Int64("id", 0, "DLQ message ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id <= 0 {
+		return fmt.Errorf("-id is required")
+	}
+
+	message, err := dlqService.GetMessage(ctx, *id)
+	if err != nil {
+		return err
+	}
+	return printJSON(out, message)
+}
+
+func replay(ctx context.Context, dlqService *service.DLQService, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(out)
+	id := fs.Int64("id", 0, "DLQ message ID")
+	bodyFile := fs.String("body-file", "", "JSON file with an edited body to publish instead of the original")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id <= 0 {
+		return fmt.Errorf("-id is required")
+	}
+
+	var body json.RawMessage
+	if *bodyFile != "" {
+		b, err := os.ReadFile(*bodyFile)
+		if err != nil {
+			return err
+		}
+		body = b
+	}
+
+	message, err := dlqService.ReplayMessage(ctx, *id, body)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "replayed message %d to %s (replay count %d)\n", message.ID, message.OriginalTopic, message.ReplayCount)
+	return nil
+}
+
+func resolve(ctx context.Context, dlqService *service.DLQService, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("resolve", flag.ContinueOnError)
+	fs.SetOutput(out)
+	id := fs.Int64("id", 0, "DLQ message ID")
+	note := fs.String("note", "", "resolution note")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id <= 0 {
+		return fmt.Errorf("-id is required")
+	}
+
+	message, err := dlqService.ResolveMessage(ctx, *id, *note)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "resolved message %d\n", message.ID)
+	return nil
+}
+
+func stats(ctx context.Context, dlqService *service.DLQService, out io.Writer) error {
+	counts, err := dlqService.CountMessages(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DLQ_TOPIC\tSTATUS\tCOUNT")
+	for _, c := range counts {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", c.DLQTopic, c.Status, c.Count)
+	}
+	return w.Flush()
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func truncate(s string, n int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"productfc/cmd/dlq/usecase"
+	"productfc/infrastructure/log"
+	"productfc/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DLQHandler struct {
+	DLQUsecase usecase.DLQUsecase
+}
+
+func NewDLQHandler(dlqUsecase usecase.DLQUsecase) *DLQHandler {
+	return &DLQHandler{DLQUsecase: dlqUsecase}
+}
+
+// SearchMessages godoc
+// @Summary DLQ 메시지 목록 조회
+// @Description DLQ 토픽/원본 토픽/주문 ID/에러 문구/상태로 DLQ 메시지를 검색합니다.
+// @Tags DLQ
+// @Security BearerAuth
+// @Produce json
+// @Param dlq_topic query string false "DLQ 토픽 (예: stock.updated.dlq)"
+// @Param original_topic query string false "원본 토픽"
+// @Param order_id query int false "주문 ID"
+// @Param error query string false "에러 메시지 포함 문구"
+// @Param error_class query string false "실패 분류 (processing/decode/schema)"
+// @Param status query string false "상태 (pending/replayed/resolved)"
+// @Param page query int false "페이지 번호" default(1)
+// @Param page_size query int false "페이지 크기" default(20)
+// @Success 200 {object} models.DLQSearchResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/dlq [get]
+func (h *DLQHandler) SearchMessages(c *gin.Context) {
+	params := models.DLQSearchParameter{
+		DLQTopic:      c.Query("dlq_topic"),
+		OriginalTopic: c.Query("original_topic"),
+		ErrorContains: c.Query("error"),
+		ErrorClass:    c.Query("error_class"),
+		Status:        c.Query("status"),
+	}
+
+	var err error
+	if orderIDStr := c.Query("order_id"); orderIDStr != "" {
+		params.OrderID, err = strconv.ParseInt(orderIDStr, 10, 64)
+		if err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid order_id")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order_id"})
+			return
+		}
+	}
+
+	// Pagination
+	if pageStr := c.Query("page"); pageStr != "" {
+		params.Page, err = strconv.Atoi(pageStr)
+		if err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid page")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page"})
+			return
+		}
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		params.PageSize, err = strconv.Atoi(pageSizeStr)
+		if err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid page_size")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page_size"})
+			return
+		}
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+
+	messages, totalCount, err := h.DLQUsecase.SearchMessages(c.Request.Context(), params)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error searching DLQ messages")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DLQSearchResponse{
+		Messages:   messages,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalCount: totalCount,
+		TotalPages: (totalCount + params.PageSize - 1) / params.PageSize,
+	})
+}
+
+// GetMessage godoc
+// @Summary DLQ 메시지 단건 조회
+// @Description DLQ 메시지 ID로 원본 본문과 에러를 조회합니다.
+// @Tags DLQ
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "DLQ 메시지 ID"
+// @Success 200 {object} models.DLQMessage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/dlq/{id} [get]
+func (h *DLQHandler) GetMessage(c *gin.Context) {
+	id, ok := parseMessageID(c)
+	if !ok {
+		return
+	}
+
+	message, err := h.DLQUsecase.GetMessage(c.Request.Context(), id)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error getting DLQ message")
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, message)
+}
+
+// ReplayMessage godoc
+// @Summary DLQ 메시지 재처리
+// @Description DLQ 메시지를 원본 토픽으로 다시 발행합니다. body를 주면 수정한 본문을 JSON(content-type application/json)으로, 새 이벤트 ID를 붙여 발행합니다.
+// @Tags DLQ
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "DLQ 메시지 ID"
+// @Param body body models.DLQReplayRequest false "재처리 요청 (수정 본문)"
+// @Success 200 {object} models.DLQMessage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/dlq/{id}/replay [post]
+func (h *DLQHandler) ReplayMessage(c *gin.Context) {
+	id, ok := parseMessageID(c)
+	if !ok {
+		return
+	}
+
+	var req models.DLQReplayRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid JSON format")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	message, err := h.DLQUsecase.ReplayMessage(c.Request.Context(), id, req.Body)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error replaying DLQ message")
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, message)
+}
+
+// ResolveMessage godoc
+// @Summary DLQ 메시지 해결 처리
+// @Description 재처리 없이 DLQ 메시지를 해결됨(resolved)으로 표시합니다.
+// @Tags DLQ
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "DLQ 메시지 ID"
+// @Param body body models.DLQResolveRequest false "해결 메모"
+// @Success 200 {object} models.DLQMessage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/dlq/{id}/resolve [post]
+func (h *DLQHandler) ResolveMessage(c *gin.Context) {
+	id, ok := parseMessageID(c)
+	if !ok {
+		return
+	}
+
+	var req models.DLQResolveRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid JSON format")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	message, err := h.DLQUsecase.ResolveMessage(c.Request.Context(), id, req.Note)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error resolving DLQ message")
+		c.JSON(statusFromError(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, message)
+}
+
+// GetStats godoc
+// @Summary DLQ 통계
+// @Description DLQ 토픽/상태별 메시지 건수를 조회합니다.
+// @Tags DLQ
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.DLQCount
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/dlq/stats [get]
+func (h *DLQHandler) GetStats(c *gin.Context) {
+	counts, err := h.DLQUsecase.CountMessages(c.Request.Context())
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error counting DLQ messages")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, counts)
+}
+
+func parseMessageID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Str("id", c.Param("id")).Msg("Invalid DLQ message id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid DLQ message id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func statusFromError(err error) int {
+	switch {
+	case errors.Is(err, models.ErrDLQMessageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrDLQMessageResolved):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrDLQInvalidReplay):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"productfc/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsertMessage — 같은 (dlq_topic, partition, offset)은 한 번만 저장 (재수집 시 무시).
+func (r *DLQRepository) InsertMessage(ctx context.Context, message *models.DLQMessage) error {
+	return r.Database.WithContext(ctx).Table("dlq_messages").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(message).Error
+}
+
+func (r *DLQRepository) FindMessageByID(ctx context.Context, id int64) (*models.DLQMessage, error) {
+	var message models.DLQMessage
+	err := r.Database.WithContext(ctx).Table("dlq_messages").Where("id = ?", id).First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrDLQMessageNotFound
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *DLQRepository) SearchMessages(ctx context.Context, params models.DLQSearchParameter) ([]models.DLQMessage, int, error) {
+	var messages []models.DLQMessage
+	var totalCount int64
+	query := r.Database.WithContext(ctx).Table("dlq_messages")
+
+	if params.DLQTopic != "" {
+		query = query.Where("dlq_topic = ?", params.DLQTopic)
+	}
+	if params.OriginalTopic != "" {
+		query = query.Where("original_topic = ?", params.OriginalTopic)
+	}
+	if params.OrderID != 0 {
+		query = query.Where("order_id = ?", params.OrderID)
+	}
+	if params.ErrorContains != "" {
+		query = query.Where("error ILIKE ?", "%"+params.ErrorContains+"%")
+	}
+	if params.ErrorClass != "" {
+		query = query.Where("error_class = ?", params.ErrorClass)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	//pagination
+	query.Model(&models.DLQMessage{}).Count(&totalCount)
+
+	offset := (params.Page - 1) * params.PageSize
+	err := query.Order("id DESC").Offset(offset).Limit(params.PageSize).Find(&messages).Error
+	if err != nil {
+		return messages, 0, err
+	}
+	return messages, int(totalCount), nil
+}
+
+func (r *DLQRepository) MarkMessageReplayed(ctx context.Context, id int64, replayedBody string) error {
+	now := time.Now()
+	return r.Database.WithContext(ctx).Table("dlq_messages").Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        models.DLQStatusReplayed,
+		"replay_count":  gorm.Expr("replay_count + 1"),
+		"replayed_body": replayedBody,
+		"replayed_at":   now,
+	}).Error
+}
+
+func (r *DLQRepository) MarkMessageResolved(ctx context.Context, id int64, note string) error {
+	now := time.Now()
+	return r.Database.WithContext(ctx).Table("dlq_messages").Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       models.DLQStatusResolved,
+		"resolve_note": note,
+		"resolved_at":  now,
+	}).Error
+}
+
+func (r *DLQRepository) CountMessagesByTopicAndStatus(ctx context.Context) ([]models.DLQCount, error) {
+	var counts []models.DLQCount
+	err := r.Database.WithContext(ctx).Table("dlq_messages").
+		Select("dlq_topic", "status", "COUNT(*) AS count").
+		Group("dlq_topic, status").
+		Order("dlq_topic, status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
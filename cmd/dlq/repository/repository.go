@@ -0,0 +1,11 @@
+package repository
+
+import "gorm.io/gorm"
+
+type DLQRepository struct {
+	Database *gorm.DB
+}
+
+func NewDLQRepository(db *gorm.DB) *DLQRepository {
+	return &DLQRepository{Database: db}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"productfc/infrastructure/log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dlqMessages = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "commerce",
+			Subsystem: "dlq",
+			Name:      "messages",
+			Help:      "DLQ messages currently stored, by DLQ topic and status",
+		},
+		[]string{"dlq_topic", "status"},
+	)
+	dlqMessagesIngested = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "dlq",
+			Name:      "messages_ingested_total",
+			Help:      "DLQ messages collected from DLQ topics",
+		},
+		[]string{"dlq_topic"},
+	)
+	dlqMessagesReplayed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "dlq",
+			Name:      "messages_replayed_total",
+			Help:      "DLQ messages replayed to their original topic",
+		},
+		[]string{"dlq_topic"},
+	)
+)
+
+// RefreshMetrics — DLQ 토픽/상태별 건수 게이지를 주기적으로 DB 기준으로 갱신 (ctx 취소 시 종료).
+func (s *DLQService) RefreshMetrics(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		counts, err := s.CountMessages(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Logger.Error().Err(err).Msg("Failed to refresh DLQ metrics")
+		} else {
+			dlqMessages.Reset()
+			for _, c := range counts {
+				dlqMessages.WithLabelValues(c.DLQTopic, c.Status).Set(float64(c.Count))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
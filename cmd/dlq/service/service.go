@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"productfc/cmd/dlq/repository"
+	"productfc/config"
+	kafkapkg "productfc/kafka"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/dlq"
+	"productfc/models"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+type DLQService struct {
+	DLQRepo  repository.DLQRepository
+	Producer *kafkapkg.Producer
+}
+
+func NewDLQService(dlqRepo repository.DLQRepository, producer *kafkapkg.Producer) *DLQService {
+	return &DLQService{DLQRepo: dlqRepo, Producer: producer}
+}
+
+// IngestMessage — DLQ 토픽에서 읽은 래핑 메시지를 조회용 테이블에 저장.
+func (s *DLQService) IngestMessage(ctx context.Context, msg kafka.Message) error {
+	var wrapped dlq.Message
+	if err := json.Unmarshal(msg.Value, &wrapped); err != nil {
+		// 래핑 형식이 아니어도 원문은 보존
+		wrapped = dlq.Message{Error: fmt.Sprintf("invalid dlq envelope: %v", err), RawBody: msg.Value}
+	}
+
+	message := &models.DLQMessage{
+		DLQTopic:          msg.Topic,
+		Partition:         msg.Partition,
+		Offset:            msg.Offset,
+		OriginalTopic:     wrapped.OriginalTopic,
+		OriginalPartition: wrapped.OriginalPartition,
+		OriginalOffset:    wrapped.OriginalOffset,
+		MessageKey:        wrapped.Key,
+		ConsumerGroup:     wrapped.ConsumerGroup,
+		Attempts:          wrapped.Attempts,
+		ErrorClass:        wrapped.ErrorClass,
+		OrderID:           orderIDFromBody(wrapped.Body),
+		Error:             wrapped.Error,
+		Body:              string(wrapped.Body),
+		BodyEncoding:      models.DLQBodyEncodingJSON,
+		Status:            models.DLQStatusPending,
+	}
+	if len(wrapped.Body) == 0 && len(wrapped.RawBody) > 0 {
+		message.Body = base64.StdEncoding.EncodeToString(wrapped.RawBody)
+		message.BodyEncoding = models.DLQBodyEncodingBase64
+	}
+	if len(wrapped.Headers) > 0 {
+		headers, err := json.Marshal(wrapped.Headers)
+		if err != nil {
+			return err
+		}
+		message.Headers = string(headers)
+	}
+	if !wrapped.FirstFailedAt.IsZero() {
+		message.FirstFailedAt = &wrapped.FirstFailedAt
+	}
+	if !wrapped.FailedAt.IsZero() {
+		message.FailedAt = &wrapped.FailedAt
+	}
+	if err := s.DLQRepo.InsertMessage(ctx, message); err != nil {
+		return err
+	}
+	dlqMessagesIngested.WithLabelValues(msg.Topic).Inc()
+	return nil
+}
+
+func (s *DLQService) GetMessage(ctx context.Context, id int64) (*models.DLQMessage, error) {
+	return s.DLQRepo.FindMessageByID(ctx, id)
+}
+
+func (s *DLQService) SearchMessages(ctx context.Context, params models.DLQSearchParameter) ([]models.DLQMessage, int, error) {
+	messages, totalCount, err := s.DLQRepo.SearchMessages(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return messages, totalCount, nil
+}
+
+// ReplayMessage — 원본 토픽으로 다시 발행. body가 있으면 수정본으로 대체.
+func (s *DLQService) ReplayMessage(ctx context.Context, id int64, body json.RawMessage) (*models.DLQMessage, error) {
+	message, err := s.DLQRepo.FindMessageByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Status == models.DLQStatusResolved {
+		return nil, models.ErrDLQMessageResolved
+	}
+	if message.OriginalTopic == "" {
+		return nil, fmt.Errorf("%w: no original topic", models.ErrDLQInvalidReplay)
+	}
+
+	msg, err := replayMessage(message, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Producer.PublishRaw(ctx, msg.Topic, msg.Key, msg.Value, msg.Headers...); err != nil {
+		return nil, err
+	}
+	if err := s.DLQRepo.MarkMessageReplayed(ctx, id, string(msg.Value)); err != nil {
+		return nil, err
+	}
+	dlqMessagesReplayed.WithLabelValues(message.DLQTopic).Inc()
+
+	return s.DLQRepo.FindMessageByID(ctx, id)
+}
+
+func (s *DLQService) ResolveMessage(ctx context.Context, id int64, note string) (*models.DLQMessage, error) {
+	if _, err := s.DLQRepo.FindMessageByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.DLQRepo.MarkMessageResolved(ctx, id, note); err != nil {
+		return nil, err
+	}
+	return s.DLQRepo.FindMessageByID(ctx, id)
+}
+
+func (s *DLQService) CountMessages(ctx context.Context) ([]models.DLQCount, error) {
+	return s.DLQRepo.CountMessagesByTopicAndStatus(ctx)
+}
+
+// replayMessage — 원본 토픽으로 보낼 메시지. 원본 키/헤더 유지 (파티션 순서, 트레이스 등).
+// body로 본문을 바꾸면 원본의 content-type(protobuf/avro, structured 봉투)과 이벤트 ID가 맞지 않으므로
+// content-type은 application/json으로 바꾸고, CloudEvents면 binary 헤더로 새 id를 붙이고 아니면 x-event-id를 새로 발급
+// (같은 ID면 컨슈머 멱등성 저장소가 수정본을 이미 처리한 이벤트로 건너뜀).
+func replayMessage(message *models.DLQMessage, body json.RawMessage) (kafka.Message, error) {
+	payload := []byte(message.Body)
+	if message.BodyEncoding == models.DLQBodyEncodingBase64 {
+		var err error
+		if payload, err = base64.StdEncoding.DecodeString(message.Body); err != nil {
+			return kafka.Message{}, fmt.Errorf("%w: %v", models.ErrDLQInvalidReplay, err)
+		}
+	}
+
+	var headers []kafka.Header
+	if message.Headers != "" {
+		var original map[string]string
+		if err := json.Unmarshal([]byte(message.Headers), &original); err != nil {
+			return kafka.Message{}, fmt.Errorf("%w: invalid headers: %v", models.ErrDLQInvalidReplay, err)
+		}
+		for key, value := range original {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+	}
+
+	msg := kafka.Message{Topic: message.OriginalTopic, Value: payload, Headers: headers}
+	if message.MessageKey != "" {
+		msg.Key = []byte(message.MessageKey)
+	}
+	if len(body) == 0 {
+		return msg, nil
+	}
+	if !json.Valid(body) {
+		return kafka.Message{}, fmt.Errorf("%w: replay body is not valid JSON", models.ErrDLQInvalidReplay)
+	}
+
+	// 원본 CloudEvents 속성 (binary 헤더 또는 structured 봉투). 원본이 잘못된 봉투면 레거시로 취급.
+	_, ce, err := cloudevents.Unwrap(msg)
+	if err != nil {
+		ce = nil
+	}
+	msg.Value = body
+	msg.Headers = msg.Headers[:0:0]
+	for _, h := range headers {
+		if !cloudevents.IsHeader(h.Key) && h.Key != kafkapkg.HeaderEventID {
+			msg.Headers = append(msg.Headers, h)
+		}
+	}
+	if ce == nil {
+		msg.Headers = append(msg.Headers,
+			kafka.Header{Key: cloudevents.HeaderContentType, Value: []byte(cloudevents.ContentTypeJSON)},
+			kafka.Header{Key: kafkapkg.HeaderEventID, Value: []byte(uuid.NewString())},
+		)
+		return msg, nil
+	}
+
+	event := *ce
+	event.ID = ""
+	event.DataContentType = cloudevents.ContentTypeJSON
+	event.DataSchema = ""
+	if err := replayEncoder.Encode(msg.Topic, &msg, event); err != nil {
+		return kafka.Message{}, fmt.Errorf("%w: %v", models.ErrDLQInvalidReplay, err)
+	}
+	return msg, nil
+}
+
+// replayEncoder — 수정 본문 재처리용 binary mode 인코더 (원본 source/type 유지, id만 새로 발급).
+var replayEncoder, _ = cloudevents.NewEncoder(config.KafkaCloudEventsConfig{Mode: cloudevents.ModeBinary}, nil)
+
+// orderIDFromBody — 본문 JSON의 order_id (없으면 0, 검색 필터용).
+func orderIDFromBody(body []byte) int64 {
+	var probe struct {
+		OrderID int64 `json:"order_id"`
+	}
+	if err := json.Unmarshal(cloudevents.DataOf(body), &probe); err != nil {
+		return 0
+	}
+	return probe.OrderID
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	kafkapkg "productfc/kafka"
+	"productfc/kafka/cloudevents"
+	"productfc/models"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func headerValue(msg kafka.Message, key string) (string, bool) {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+func headersJSON(t *testing.T, headers map[string]string) string {
+	t.Helper()
+	b, err := json.Marshal(headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestReplayMessageKeepsOriginalWithoutBody(t *testing.T) {
+	raw := []byte{0x0a, 0x02, 0x08, 0x01}
+	message := &models.DLQMessage{
+		OriginalTopic: "order.created",
+		MessageKey:    "order-1",
+		Body:          base64.StdEncoding.EncodeToString(raw),
+		BodyEncoding:  models.DLQBodyEncodingBase64,
+		Headers: headersJSON(t, map[string]string{
+			"content-type":   "application/x-protobuf",
+			"ce_specversion": "1.0",
+			"ce_id":          "evt-1",
+			"traceparent":    "00-abc-def-01",
+		}),
+	}
+	msg, err := replayMessage(message, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg.Value) != string(raw) || string(msg.Key) != "order-1" || msg.Topic != "order.created" {
+		t.Fatalf("replay = %s/%s/%x, want original", msg.Topic, msg.Key, msg.Value)
+	}
+	for key, want := range map[string]string{"content-type": "application/x-protobuf", "ce_id": "evt-1", "traceparent": "00-abc-def-01"} {
+		if got, _ := headerValue(msg, key); got != want {
+			t.Fatalf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestReplayMessageWithEditedBody(t *testing.T) {
+	edited := json.RawMessage(`{"schema_version":1,"order_id":1,"user_id":2,"products":[]}`)
+	tests := []struct {
+		name     string
+		message  models.DLQMessage
+		oldID    string
+		wantCE   bool
+		wantType string
+	}{
+		{
+			name: "binary CloudEvents protobuf original",
+			message: models.DLQMessage{
+				Body:         base64.StdEncoding.EncodeToString([]byte{0x0a, 0x01}),
+				BodyEncoding: models.DLQBodyEncodingBase64,
+				Headers: `{"content-type":"application/x-protobuf","ce_specversion":"1.0","ce_id":"evt-1",` +
+					`"ce_source":"/orderfc","ce_type":"com.gocommerce.order.created","ce_subject":"1",` +
+					`"ce_time":"2025-01-02T03:04:05Z","ce_dataschema":"https://productfc/schemas/order.created.v1.json",` +
+					`"traceparent":"00-abc-def-01"}`,
+			},
+			oldID:    "evt-1",
+			wantCE:   true,
+			wantType: "com.gocommerce.order.created",
+		},
+		{
+			name: "structured CloudEvents original",
+			message: models.DLQMessage{
+				Body: `{"specversion":"1.0","id":"evt-2","source":"/orderfc","type":"com.gocommerce.order.created",` +
+					`"datacontenttype":"application/json","data":{"order_id":1}}`,
+				BodyEncoding: models.DLQBodyEncodingJSON,
+				Headers:      `{"content-type":"application/cloudevents+json","traceparent":"00-abc-def-01"}`,
+			},
+			oldID:    "evt-2",
+			wantCE:   true,
+			wantType: "com.gocommerce.order.created",
+		},
+		{
+			name: "legacy avro original with event id",
+			message: models.DLQMessage{
+				Body:         base64.StdEncoding.EncodeToString([]byte{0x02}),
+				BodyEncoding: models.DLQBodyEncodingBase64,
+				Headers:      `{"content-type":"application/avro","x-event-id":"evt-3","traceparent":"00-abc-def-01"}`,
+			},
+			oldID: "evt-3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := tt.message
+			message.OriginalTopic = "order.created"
+			message.MessageKey = "order-1"
+			msg, err := replayMessage(&message, edited)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(msg.Key) != "order-1" {
+				t.Fatalf("key = %q, want order-1", msg.Key)
+			}
+			if got, _ := headerValue(msg, "content-type"); got != cloudevents.ContentTypeJSON {
+				t.Fatalf("content-type = %q, want %q", got, cloudevents.ContentTypeJSON)
+			}
+			if got, _ := headerValue(msg, "traceparent"); got != "00-abc-def-01" {
+				t.Fatalf("traceparent = %q, want original", got)
+			}
+			if _, ok := headerValue(msg, "ce_dataschema"); ok {
+				t.Fatal("ce_dataschema kept for edited body")
+			}
+
+			body, ce, err := cloudevents.Unwrap(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != string(edited) {
+				t.Fatalf("body = %s, want edited body", body)
+			}
+			if (ce != nil) != tt.wantCE {
+				t.Fatalf("CloudEvents = %v, want %v", ce != nil, tt.wantCE)
+			}
+			if ce != nil {
+				if ce.Mode != cloudevents.ModeBinary || ce.Type != tt.wantType || ce.Source != "/orderfc" {
+					t.Fatalf("event = %+v, want binary %s from /orderfc", ce, tt.wantType)
+				}
+				if ce.ID == "" || ce.ID == tt.oldID {
+					t.Fatalf("ce id = %q, want a new id", ce.ID)
+				}
+				if _, ok := headerValue(msg, kafkapkg.HeaderEventID); ok {
+					t.Fatal("x-event-id set on CloudEvents replay")
+				}
+				return
+			}
+			if id, _ := headerValue(msg, kafkapkg.HeaderEventID); id == "" || id == tt.oldID {
+				t.Fatalf("x-event-id = %q, want a new id", id)
+			}
+		})
+	}
+}
+
+func TestReplayMessageRejectsInvalidBody(t *testing.T) {
+	message := &models.DLQMessage{OriginalTopic: "order.created", Body: `{}`, BodyEncoding: models.DLQBodyEncodingJSON}
+	if _, err := replayMessage(message, json.RawMessage(`{`)); err == nil {
+		t.Fatal("replayMessage error = nil, want invalid body error")
+	}
+}
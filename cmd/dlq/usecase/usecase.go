@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"productfc/cmd/dlq/service"
+	"productfc/models"
+)
+
+type DLQUsecase struct {
+	DLQService service.DLQService
+}
+
+func NewDLQUsecase(dlqService service.DLQService) *DLQUsecase {
+	return &DLQUsecase{DLQService: dlqService}
+}
+
+func (u *DLQUsecase) GetMessage(ctx context.Context, id int64) (*models.DLQMessage, error) {
+	return u.DLQService.GetMessage(ctx, id)
+}
+
+func (u *DLQUsecase) SearchMessages(ctx context.Context, params models.DLQSearchParameter) ([]models.DLQMessage, int, error) {
+	messages, totalCount, err := u.DLQService.SearchMessages(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return messages, totalCount, nil
+}
+
+func (u *DLQUsecase) ReplayMessage(ctx context.Context, id int64, body json.RawMessage) (*models.DLQMessage, error) {
+	return u.DLQService.ReplayMessage(ctx, id, body)
+}
+
+func (u *DLQUsecase) ResolveMessage(ctx context.Context, id int64, note string) (*models.DLQMessage, error) {
+	return u.DLQService.ResolveMessage(ctx, id, note)
+}
+
+func (u *DLQUsecase) CountMessages(ctx context.Context) ([]models.DLQCount, error) {
+	return u.DLQService.CountMessages(ctx)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"productfc/cmd/product/usecase"
@@ -437,3 +438,416 @@ func (h *ProductHandler) SearchProducts(c *gin.Context) {
 		NextPageUrl: nextPageUrl,
 	})
 }
+
+// SetProductStockThreshold godoc
+// @Summary 상품 저재고 임계치 설정
+// @Description 상품별 저재고 임계치를 설정합니다. 재고가 임계치 이하로 내려가면 stock.low 이벤트가 발행됩니다. (카테고리 설정보다 우선)
+// @Tags PRODUCT
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "상품 ID"
+// @Param body body models.StockThresholdRequest true "임계치 설정 요청"
+// @Success 200 {object} models.StockThreshold
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/products/{id}/stock-threshold [put]
+func (h *ProductHandler) SetProductStockThreshold(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	var req models.StockThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	threshold, err := h.ProductUsecase.SetProductStockThreshold(c.Request.Context(), id, req.LowStockThreshold)
+	if err != nil {
+		if errors.Is(err, models.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Info().Err(err).Msg("Error setting product stock threshold")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, threshold)
+}
+
+// SetCategoryStockThreshold godoc
+// @Summary 카테고리 저재고 임계치 설정
+// @Description 카테고리에 속한 상품의 기본 저재고 임계치를 설정합니다.
+// @Tags PRODUCT
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "카테고리 ID"
+// @Param body body models.StockThresholdRequest true "임계치 설정 요청"
+// @Success 200 {object} models.StockThreshold
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/product-categories/{id}/stock-threshold [put]
+func (h *ProductHandler) SetCategoryStockThreshold(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid category id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category id"})
+		return
+	}
+
+	var req models.StockThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	threshold, err := h.ProductUsecase.SetCategoryStockThreshold(c.Request.Context(), id, req.LowStockThreshold)
+	if err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Info().Err(err).Msg("Error setting category stock threshold")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, threshold)
+}
+
+// GetBackorderSetting godoc
+// @Summary 상품 백오더 설정 조회
+// @Description 상품의 백오더/예약 주문 설정을 조회합니다. 설정이 없으면 허용 안 함(allowed=false)으로 반환합니다.
+// @Tags PRODUCT
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "상품 ID"
+// @Success 200 {object} models.BackorderSetting
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/products/{id}/backorder [get]
+func (h *ProductHandler) GetBackorderSetting(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	setting, err := h.ProductUsecase.GetBackorderSetting(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Info().Err(err).Msg("Error getting backorder setting")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, setting)
+}
+
+// SetBackorderSetting godoc
+// @Summary 상품 백오더 설정
+// @Description 재고가 없어도 주문을 받을지(백오더/예약 주문), 최대 백오더 수량(0이면 무제한), 입고 예정일을 설정합니다.
+// @Description 백오더로 받은 수량은 stock.backordered 이벤트로 발행되고, 입고되면 먼저 받은 주문부터 할당됩니다.
+// @Tags PRODUCT
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "상품 ID"
+// @Param body body models.BackorderSettingRequest true "백오더 설정 요청"
+// @Success 200 {object} models.BackorderSetting
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/products/{id}/backorder [put]
+func (h *ProductHandler) SetBackorderSetting(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	var req models.BackorderSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setting, err := h.ProductUsecase.SetBackorderSetting(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, models.ErrProductNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Info().Err(err).Msg("Error setting backorder setting")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, setting)
+}
+
+// AdjustProductStock godoc
+// @Summary 상품 재고 조정
+// @Description 상품 재고를 상대 수량만큼 조정하고 조정 이력을 남깁니다. 사유: damage(파손, 감소만), recount(재집계), receipt(입고, 증가만).
+// @Description 늘어난 수량은 대기 중인 백오더에 먼저 할당되며, 실물 재고(재고 + 미할당 백오더)가 음수가 되는 조정은 409를 반환합니다.
+// @Tags PRODUCT
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "상품 ID"
+// @Param body body models.StockAdjustmentRequest true "재고 조정 요청"
+// @Success 200 {object} models.StockAdjustment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/products/{id}/stock-adjustments [post]
+func (h *ProductHandler) AdjustProductStock(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	var req models.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	adjustment, err := h.ProductUsecase.AdjustProductStock(c.Request.Context(), id, req, int64(c.GetFloat64("user_id")))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidAdjustment):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrNegativeAdjustment):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			log.Logger.Info().Err(err).Msg("Error adjusting product stock")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, adjustment)
+}
+
+// ApplyStocktake godoc
+// @Summary 재고 실사 일괄 반영
+// @Description 실사한 실물 수량(절대값)으로 여러 상품의 재고를 한 번에 반영하고 상품별 차이(variance = counted - expected)를 반환합니다.
+// @Description expected는 재고 + 미할당 백오더이며, 미할당 백오더 수량은 반영 후에도 음수 재고로 유지됩니다.
+// @Description 없는 상품이 하나라도 있으면 아무것도 반영하지 않습니다.
+// @Tags PRODUCT
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body models.StocktakeRequest true "실사 업로드 요청"
+// @Success 200 {object} models.StocktakeResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/products/stocktake [post]
+func (h *ProductHandler) ApplyStocktake(c *gin.Context) {
+	var req models.StocktakeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.ProductUsecase.ApplyStocktake(c.Request.Context(), req, int64(c.GetFloat64("user_id")))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidStocktake):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Logger.Info().Err(err).Msg("Error applying stocktake")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CreatePurchaseOrder godoc
+// @Summary 발주 생성
+// @Description 상품별 발주 수량과 입고 예정일로 발주를 생성합니다. 라인에 expected_at이 없으면 발주의 expected_at을 사용합니다.
+// @Description 입고되지 않은 수량은 상품 조회 응답의 incoming / restock_eta로 노출됩니다.
+// @Tags PURCHASE_ORDER
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body models.CreatePurchaseOrderRequest true "발주 생성 요청"
+// @Success 201 {object} models.PurchaseOrder
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/purchase-orders [post]
+func (h *ProductHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req models.CreatePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.ProductUsecase.CreatePurchaseOrder(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidPurchaseOrder):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Logger.Info().Err(err).Msg("Error creating purchase order")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, order)
+}
+
+// GetPurchaseOrder godoc
+// @Summary 발주 조회
+// @Description 발주와 라인별 입고 현황을 조회합니다.
+// @Tags PURCHASE_ORDER
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "발주 ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/purchase-orders/{id} [get]
+func (h *ProductHandler) GetPurchaseOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid purchase order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order id"})
+		return
+	}
+
+	order, err := h.ProductUsecase.GetPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrPurchaseOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Info().Err(err).Msg("Error getting purchase order")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary 발주 입고
+// @Description 발주 라인별 입고 수량을 재고에 가산합니다. lines를 비우면 남은 수량 전부를 입고합니다.
+// @Description 입고된 수량은 대기 중인 백오더에 먼저 할당되며, 남은 수량을 넘는 입고나 종료된 발주는 거부됩니다.
+// @Tags PURCHASE_ORDER
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "발주 ID"
+// @Param body body models.ReceivePurchaseOrderRequest true "입고 요청"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/purchase-orders/{id}/receipts [post]
+func (h *ProductHandler) ReceivePurchaseOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid purchase order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order id"})
+		return
+	}
+
+	var req models.ReceivePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.ProductUsecase.ReceivePurchaseOrder(c.Request.Context(), id, req, int64(c.GetFloat64("user_id")))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPurchaseOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidReceipt):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrPurchaseOrderClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			log.Logger.Info().Err(err).Msg("Error receiving purchase order")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary 발주 취소
+// @Description 남은 수량을 더 이상 입고하지 않도록 발주를 취소합니다. 이미 입고된 수량은 그대로 유지됩니다.
+// @Tags PURCHASE_ORDER
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "발주 ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/purchase-orders/{id}/cancel [post]
+func (h *ProductHandler) CancelPurchaseOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		log.Logger.Info().Err(err).Msg("Invalid purchase order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order id"})
+		return
+	}
+
+	order, err := h.ProductUsecase.CancelPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPurchaseOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrPurchaseOrderClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			log.Logger.Info().Err(err).Msg("Error cancelling purchase order")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"productfc/models"
+
+	"gorm.io/gorm"
+)
+
+// AdjustProductStock — 관리자 상대 조정. AddProductStockByProductID와 같이 상품 행을 잠그고,
+// 늘리는 조정은 입고와 같이 대기 백오더에 할당. 줄이는 조정은 실물 재고(재고 + 미할당 백오더)가
+// 음수가 되면 models.ErrNegativeAdjustment (백오더로 음수인 재고도 실물이 있으면 줄일 수 있음).
+// adjustment에 조정 전후 재고를 채워 이력으로 저장.
+func (r *ProductRepository) AdjustProductStock(ctx context.Context, adjustment *models.StockAdjustment) (models.StockChange, error) {
+	changes := newStockChanges()
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if adjustment.Delta > 0 {
+			if err := addStocks(tx, []models.ProductItem{{ProductID: adjustment.ProductID, Quantity: adjustment.Delta}}, nil, changes); err != nil {
+				return err
+			}
+		} else {
+			products, err := lockProducts(tx, []int64{adjustment.ProductID})
+			if err != nil {
+				return err
+			}
+			product := products[0]
+			pending, err := pendingBackorders(tx, []int64{product.ID})
+			if err != nil {
+				return err
+			}
+			if err := checkDecrease(product, pending[product.ID], adjustment.Delta); err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Product{}).Where("id = ?", product.ID).
+				Update("stock", gorm.Expr("stock + ?", adjustment.Delta)).Error; err != nil {
+				return err
+			}
+			changes.add(product, adjustment.Delta)
+		}
+
+		change := changes.list()[0]
+		adjustment.OldStock = change.OldStock
+		adjustment.NewStock = change.NewStock
+		return tx.Create(adjustment).Error
+	})
+	if err != nil {
+		return models.StockChange{}, err
+	}
+	return changes.list()[0], nil
+}
+
+// ApplyStocktake — 실사 수량을 실물 재고로 반영하고 상품별 차이 반환 (요청 순서, 상품 ID 중복 없음).
+// 실물 재고는 재고 + 미할당 백오더 (백오더만큼 음수인 재고는 실물 0), 반영 후에도 미할당 백오더만큼은 음수로 남김.
+// 상품을 ID 순으로 한 번에 잠그고, 차이가 없는 상품도 실사 이력은 남김.
+// 늘어난 실물만 입고와 같이 대기 백오더에 할당. template의 Reason/Note/StocktakeID/AdjustedBy를 이력 행에 복사.
+func (r *ProductRepository) ApplyStocktake(ctx context.Context, items []models.StocktakeItem, template models.StockAdjustment) ([]models.StocktakeVariance, []models.StockChange, error) {
+	ids := make([]int64, 0, len(items))
+	counted := make(map[int64]int, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+		counted[item.ProductID] = *item.Counted
+	}
+	slices.Sort(ids)
+
+	changes := newStockChanges()
+	expected := make(map[int64]int, len(ids))
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		products, err := lockProducts(tx, ids)
+		if err != nil {
+			return err
+		}
+		pending, err := pendingBackorders(tx, ids)
+		if err != nil {
+			return err
+		}
+
+		adjustments := make([]models.StockAdjustment, 0, len(products))
+		for _, product := range products {
+			var variance, newStock int
+			expected[product.ID], variance, newStock = planStocktake(product.Stock, pending[product.ID], counted[product.ID])
+
+			adjustment := template
+			adjustment.ProductID = product.ID
+			adjustment.Delta = variance
+			adjustment.OldStock = product.Stock
+			adjustment.NewStock = newStock
+			adjustments = append(adjustments, adjustment)
+			if variance == 0 {
+				continue
+			}
+
+			if err := tx.Model(&models.Product{}).Where("id = ?", product.ID).
+				Update("stock", adjustment.NewStock).Error; err != nil {
+				return err
+			}
+			changes.add(product, variance)
+			if variance > 0 {
+				allocations, err := allocateBackorders(tx, product.ID, variance)
+				if err != nil {
+					return err
+				}
+				changes.allocate(product.ID, allocations)
+			}
+		}
+		return tx.Create(&adjustments).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variances := make([]models.StocktakeVariance, 0, len(items))
+	for _, item := range items {
+		variances = append(variances, models.StocktakeVariance{
+			ProductID: item.ProductID,
+			Expected:  expected[item.ProductID],
+			Counted:   *item.Counted,
+			Variance:  *item.Counted - expected[item.ProductID],
+		})
+	}
+	return variances, changes.list(), nil
+}
+
+// onHandStock — 실물 재고: 재고 + 미할당 백오더 (백오더만큼 음수인 재고는 실물 0).
+func onHandStock(stock, pending int) int {
+	return stock + pending
+}
+
+// checkDecrease — 줄이는 조정(delta < 0)이 실물 재고를 음수로 만들면 models.ErrNegativeAdjustment.
+func checkDecrease(product models.Product, pending, delta int) error {
+	if onHand := onHandStock(product.Stock, pending); onHand+delta < 0 {
+		return fmt.Errorf("%w: product %d on_hand=%d, delta=%d", models.ErrNegativeAdjustment, product.ID, onHand, delta)
+	}
+	return nil
+}
+
+// planStocktake — 실사 수량 counted 기준 기대 실물 재고, 차이, 반영할 재고 (미할당 백오더만큼 음수는 유지).
+func planStocktake(stock, pending, counted int) (expected, variance, newStock int) {
+	expected = onHandStock(stock, pending)
+	variance = counted - expected
+	return expected, variance, stock + variance
+}
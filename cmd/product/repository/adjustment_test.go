@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"productfc/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPlanStocktake(t *testing.T) {
+	tests := []struct {
+		name                              string
+		stock, pending, counted           int
+		wantExpected, wantVariance, wantN int
+	}{
+		{name: "no backorders, matches", stock: 5, counted: 5, wantExpected: 5, wantVariance: 0, wantN: 5},
+		{name: "no backorders, shrinkage", stock: 5, counted: 3, wantExpected: 5, wantVariance: -2, wantN: 3},
+		{name: "negative stock fully backordered, nothing on shelf", stock: -3, pending: 3, counted: 0, wantExpected: 0, wantVariance: 0, wantN: -3},
+		{name: "negative stock, found units keep liability", stock: -3, pending: 3, counted: 2, wantExpected: 0, wantVariance: 2, wantN: -1},
+		{name: "negative stock with some on hand, shrinkage", stock: -2, pending: 3, counted: 0, wantExpected: 1, wantVariance: -1, wantN: -3},
+		{name: "positive stock with pending backorders", stock: 1, pending: 2, counted: 3, wantExpected: 3, wantVariance: 0, wantN: 1},
+		{name: "counted covers all backorders", stock: -2, pending: 2, counted: 5, wantExpected: 0, wantVariance: 5, wantN: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, variance, newStock := planStocktake(tt.stock, tt.pending, tt.counted)
+			if expected != tt.wantExpected || variance != tt.wantVariance || newStock != tt.wantN {
+				t.Fatalf("planStocktake(%d, %d, %d) = (%d, %d, %d), want (%d, %d, %d)",
+					tt.stock, tt.pending, tt.counted, expected, variance, newStock, tt.wantExpected, tt.wantVariance, tt.wantN)
+			}
+			// 반영 후 실물 재고 = 실사 수량, 미할당 백오더만큼은 음수로 남음
+			if got := onHandStock(newStock, tt.pending); got != tt.counted {
+				t.Fatalf("on hand after stocktake = %d, want counted %d", got, tt.counted)
+			}
+		})
+	}
+}
+
+func TestCheckDecrease(t *testing.T) {
+	tests := []struct {
+		name           string
+		stock, pending int
+		delta          int
+		wantErr        bool
+	}{
+		{name: "within stock", stock: 5, delta: -5},
+		{name: "below zero without backorders", stock: 5, delta: -6, wantErr: true},
+		{name: "negative stock with units on hand", stock: -2, pending: 3, delta: -1},
+		{name: "negative stock beyond units on hand", stock: -2, pending: 3, delta: -2, wantErr: true},
+		{name: "fully backordered, nothing on hand", stock: -3, pending: 3, delta: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDecrease(models.Product{ID: 1, Stock: tt.stock}, tt.pending, tt.delta)
+			if got := errors.Is(err, models.ErrNegativeAdjustment); got != tt.wantErr {
+				t.Fatalf("err = %v, want ErrNegativeAdjustment %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// 백오더로 음수인 재고에서 실사가 실물보다 적으면 차이만큼 더 음수로 (백오더 부채 유지).
+func TestApplyStocktakeNegativeStockWithPendingBackorders(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1) ORDER BY id FOR UPDATE`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "category_id"}).AddRow(1, -2, 3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT product_id, SUM(quantity - allocated_quantity - cancelled_quantity) AS pending FROM "stock_backorders"`)).
+		WithArgs(int64(1), models.BackorderStatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "pending"}).AddRow(1, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "stock"=$1 WHERE id = $2`)).
+		WithArgs(-3, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stock_adjustments"`)).
+		WithArgs(int64(1), models.StockAdjustReasonStocktake, -1, -2, -3, "", sqlmock.AnyArg(), int64(0), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	counted := 0
+	variances, changes, err := repo.ApplyStocktake(context.Background(),
+		[]models.StocktakeItem{{ProductID: 1, Counted: &counted}},
+		models.StockAdjustment{Reason: models.StockAdjustReasonStocktake},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantVariances := []models.StocktakeVariance{{ProductID: 1, Expected: 1, Counted: 0, Variance: -1}}
+	if !reflect.DeepEqual(variances, wantVariances) {
+		t.Fatalf("variances = %+v, want %+v", variances, wantVariances)
+	}
+	if len(changes) != 1 || changes[0].OldStock != -2 || changes[0].NewStock != -3 {
+		t.Fatalf("changes = %+v, want -2 → -3", changes)
+	}
+}
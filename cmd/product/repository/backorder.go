@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"productfc/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *ProductRepository) FindBackorderSetting(ctx context.Context, productID int64) (*models.BackorderSetting, error) {
+	var setting models.BackorderSetting
+	err := r.Database.WithContext(ctx).Where("product_id = ?", productID).First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.BackorderSetting{ProductID: productID, Mode: models.BackorderModeBackorder}, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *ProductRepository) UpsertBackorderSetting(ctx context.Context, setting *models.BackorderSetting) (*models.BackorderSetting, error) {
+	setting.UpdatedAt = time.Now()
+	err := r.Database.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"allowed", "mode", "max_quantity", "expected_restock_at", "updated_at"}),
+		}).
+		Create(setting).Error
+	if err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+// backorderSettings — 백오더를 허용한 상품 설정 (없는 상품은 허용 안 함).
+func backorderSettings(tx *gorm.DB, ids []int64) (map[int64]models.BackorderSetting, error) {
+	var settings []models.BackorderSetting
+	if err := tx.Where("product_id IN ? AND allowed", ids).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[int64]models.BackorderSetting, len(settings))
+	for _, s := range settings {
+		out[s.ProductID] = s
+	}
+	return out, nil
+}
+
+// pendingBackorders — 상품별 아직 할당되지 않은 백오더 수량 (음수 재고 중 실물이 없는 부분).
+func pendingBackorders(tx *gorm.DB, ids []int64) (map[int64]int, error) {
+	var rows []struct {
+		ProductID int64
+		Pending   int
+	}
+	err := tx.Model(&models.StockBackorder{}).
+		Select("product_id, SUM(quantity - allocated_quantity - cancelled_quantity) AS pending").
+		Where("product_id IN ? AND status = ?", ids, models.BackorderStatusPending).
+		Group("product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		pending[row.ProductID] = row.Pending
+	}
+	return pending, nil
+}
+
+// allocateBackorders — 입고 수량 qty를 상품의 대기 백오더에 먼저 받은 순서로 할당.
+// 상품 행을 잠근 트랜잭션 안에서 호출 (같은 상품 입고끼리 순서 보장).
+func allocateBackorders(tx *gorm.DB, productID int64, qty int) ([]models.BackorderAllocation, error) {
+	var pending []models.StockBackorder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND status = ?", productID, models.BackorderStatusPending).
+		Order("id").Find(&pending).Error; err != nil {
+		return nil, err
+	}
+
+	allocations := planBackorderAllocation(pending, qty)
+	for _, allocation := range allocations {
+		backorder := allocation.Backorder
+		if err := tx.Model(&backorder).Updates(map[string]any{
+			"allocated_quantity": backorder.AllocatedQuantity,
+			"status":             backorder.Status,
+		}).Error; err != nil {
+			return nil, err
+		}
+	}
+	return allocations, nil
+}
+
+// planBackorderAllocation — qty를 pending(id 순, 먼저 받은 순)에 앞에서부터 남은 수량만큼 할당.
+// 할당 후 상태(allocated_quantity, 다 채우면 allocated)를 반영한 백오더와 할당 수량을 반환.
+func planBackorderAllocation(pending []models.StockBackorder, qty int) []models.BackorderAllocation {
+	var allocations []models.BackorderAllocation
+	for _, backorder := range pending {
+		if qty == 0 {
+			break
+		}
+		n := min(qty, backorder.Pending())
+		if n <= 0 {
+			continue
+		}
+		backorder.AllocatedQuantity += n
+		if backorder.Pending() == 0 {
+			backorder.Status = models.BackorderStatusAllocated
+		}
+		allocations = append(allocations, models.BackorderAllocation{Backorder: backorder, Quantity: n})
+		qty -= n
+	}
+	return allocations
+}
+
+// cancelBackorder — 롤백되는 예약 라인의 미할당 백오더를 qty까지 취소하고 취소한 수량 반환.
+func cancelBackorder(tx *gorm.DB, line models.StockReservationLine, qty int) (int, error) {
+	var backorder models.StockBackorder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("reservation_id = ? AND line_id = ? AND status = ?", line.ReservationID, line.LineID, models.BackorderStatusPending).
+		First(&backorder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	n := min(qty, backorder.Pending())
+	backorder.CancelledQuantity += n
+	status := backorder.Status
+	if backorder.Pending() == 0 {
+		status = models.BackorderStatusCancelled
+		if backorder.AllocatedQuantity > 0 {
+			status = models.BackorderStatusAllocated
+		}
+	}
+	err = tx.Model(&backorder).Updates(map[string]any{
+		"cancelled_quantity": backorder.CancelledQuantity,
+		"status":             status,
+	}).Error
+	return n, err
+}
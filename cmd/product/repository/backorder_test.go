@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"productfc/models"
+)
+
+func TestPlanBackorderAllocation(t *testing.T) {
+	pending := func() []models.StockBackorder {
+		return []models.StockBackorder{
+			{ID: 1, Quantity: 2, Status: models.BackorderStatusPending},
+			{ID: 2, Quantity: 3, CancelledQuantity: 1, Status: models.BackorderStatusPending},
+			{ID: 3, Quantity: 4, AllocatedQuantity: 1, Status: models.BackorderStatusPending},
+		}
+	}
+	type alloc struct {
+		id        int64
+		qty       int
+		allocated int
+		status    string
+	}
+	tests := []struct {
+		name string
+		qty  int
+		want []alloc
+	}{
+		{name: "nothing received", qty: 0},
+		{
+			name: "partial fill of the oldest",
+			qty:  1,
+			want: []alloc{{id: 1, qty: 1, allocated: 1, status: models.BackorderStatusPending}},
+		},
+		{
+			name: "oldest first, skipping cancelled quantity",
+			qty:  4,
+			want: []alloc{
+				{id: 1, qty: 2, allocated: 2, status: models.BackorderStatusAllocated},
+				{id: 2, qty: 2, allocated: 2, status: models.BackorderStatusAllocated},
+			},
+		},
+		{
+			name: "more than pending leaves the rest unallocated",
+			qty:  10,
+			want: []alloc{
+				{id: 1, qty: 2, allocated: 2, status: models.BackorderStatusAllocated},
+				{id: 2, qty: 2, allocated: 2, status: models.BackorderStatusAllocated},
+				{id: 3, qty: 3, allocated: 4, status: models.BackorderStatusAllocated},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []alloc
+			for _, a := range planBackorderAllocation(pending(), tt.qty) {
+				got = append(got, alloc{id: a.Backorder.ID, qty: a.Quantity, allocated: a.Backorder.AllocatedQuantity, status: a.Backorder.Status})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("allocations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 부분 입고가 여러 번 들어와도 먼저 받은 백오더부터 채워지고, 다 채운 백오더는 다음 입고에서 빠짐.
+func TestPlanBackorderAllocationAcrossPartialReceipts(t *testing.T) {
+	pending := []models.StockBackorder{
+		{ID: 1, Quantity: 3, Status: models.BackorderStatusPending},
+		{ID: 2, Quantity: 2, Status: models.BackorderStatusPending},
+		{ID: 3, Quantity: 4, Status: models.BackorderStatusPending},
+	}
+	receipts := []struct {
+		qty  int
+		want map[int64]int // 이번 입고의 백오더별 할당 수량
+	}{
+		{qty: 2, want: map[int64]int{1: 2}},
+		{qty: 2, want: map[int64]int{1: 1, 2: 1}},
+		{qty: 1, want: map[int64]int{2: 1}},
+		{qty: 6, want: map[int64]int{3: 4}},
+		{qty: 1, want: map[int64]int{}},
+	}
+	for i, receipt := range receipts {
+		got := make(map[int64]int)
+		for _, a := range planBackorderAllocation(pending, receipt.qty) {
+			got[a.Backorder.ID] = a.Quantity
+			for j := range pending {
+				if pending[j].ID == a.Backorder.ID {
+					pending[j] = a.Backorder
+				}
+			}
+		}
+		if !reflect.DeepEqual(got, receipt.want) {
+			t.Fatalf("receipt %d (%d): allocations = %v, want %v", i, receipt.qty, got, receipt.want)
+		}
+		// allocateBackorders는 pending 상태만 다시 읽음
+		still := pending[:0]
+		for _, b := range pending {
+			if b.Status == models.BackorderStatusPending {
+				still = append(still, b)
+			}
+		}
+		pending = still
+	}
+	if len(pending) != 0 {
+		t.Fatalf("pending after receipts = %+v, want none", pending)
+	}
+}
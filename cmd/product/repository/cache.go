@@ -5,10 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"productfc/infrastructure/cache"
 	"productfc/models"
 	"time"
-
-	"github.com/redis/go-redis/v9"
 )
 
 var (
@@ -16,11 +15,11 @@ var (
 	cacheKeyProductCategoryInfo = "product_category:%d"
 )
 
-func (r *ProductRepository) GetProductByIdFromRedis(ctx context.Context, productID int64) (*models.Product, error) {
+func (r *ProductRepository) GetProductByIdFromCache(ctx context.Context, productID int64) (*models.Product, error) {
 	cacheKey := fmt.Sprintf(cacheKeyProductInfo, productID)
-	productString, err := r.Redis.Get(ctx, cacheKey).Result()
+	productString, err := r.Cache.Get(ctx, cacheKey)
 	if err != nil {
-		if err == redis.Nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
 			return &models.Product{}, nil
 		}
 		return nil, err
@@ -33,11 +32,11 @@ func (r *ProductRepository) GetProductByIdFromRedis(ctx context.Context, product
 	return &product, nil
 }
 
-func (r *ProductRepository) GetProductCategoryByIdFromRedis(ctx context.Context, productCategoryID int) (*models.ProductCategory, error) {
+func (r *ProductRepository) GetProductCategoryByIdFromCache(ctx context.Context, productCategoryID int) (*models.ProductCategory, error) {
 	cacheKey := fmt.Sprintf(cacheKeyProductCategoryInfo, productCategoryID)
-	productCategoryString, err := r.Redis.Get(ctx, cacheKey).Result()
+	productCategoryString, err := r.Cache.Get(ctx, cacheKey)
 	if err != nil {
-		if err == redis.Nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
 			return nil, errors.New("product category not found")
 		}
 		return nil, err
@@ -56,7 +55,7 @@ func (r *ProductRepository) SetProductById(ctx context.Context, product *models.
 	if err != nil {
 		return errors.New("failed to marshal product to json")
 	}
-	return r.Redis.Set(ctx, cacheKey, productJSON, time.Minute*5).Err()
+	return r.Cache.Set(ctx, cacheKey, productJSON, time.Minute*5)
 }
 
 func (r *ProductRepository) SetProductCategoryById(ctx context.Context, productCategory *models.ProductCategory) error {
@@ -65,40 +64,41 @@ func (r *ProductRepository) SetProductCategoryById(ctx context.Context, productC
 	if err != nil {
 		return errors.New("failed to marshal product category to json")
 	}
-	return r.Redis.Set(ctx, cacheKey, productCategoryJSON, time.Minute*5).Err()
+	return r.Cache.Set(ctx, cacheKey, productCategoryJSON, time.Minute*5)
 }
 
 func (r *ProductRepository) InvalidateProductCache(ctx context.Context, productID int64) error {
 	cacheKey := fmt.Sprintf(cacheKeyProductInfo, productID)
-	return r.Redis.Del(ctx, cacheKey).Err()
+	return r.Cache.Del(ctx, cacheKey)
 }
 
 func (r *ProductRepository) InvalidateProductCategoryCache(ctx context.Context, categoryID int) error {
 	cacheKey := fmt.Sprintf(cacheKeyProductCategoryInfo, categoryID)
-	return r.Redis.Del(ctx, cacheKey).Err()
+	return r.Cache.Del(ctx, cacheKey)
+}
+
+// CacheDegraded — circuit breaker가 열려 캐시를 우회 중이면 true.
+func (r *ProductRepository) CacheDegraded() bool {
+	return cache.IsDegraded(r.Cache)
 }
 
 const rankingKeyProductViews = "ranking:product_views"
 
 func (r *ProductRepository) IncrementProductView(ctx context.Context, productID int64) error {
 	member := fmt.Sprintf("%d", productID)
-	return r.Redis.ZIncrBy(ctx, rankingKeyProductViews, 1, member).Err()
+	return r.Cache.ZIncrBy(ctx, rankingKeyProductViews, 1, member)
 }
 
 func (r *ProductRepository) GetTopProducts(ctx context.Context, limit int64) ([]models.ProductRankingItem, error) {
-	results, err := r.Redis.ZRevRangeWithScores(ctx, rankingKeyProductViews, 0, limit-1).Result()
+	results, err := r.Cache.ZRevRangeWithScores(ctx, rankingKeyProductViews, 0, limit-1)
 	if err != nil {
 		return nil, err
 	}
 
 	items := make([]models.ProductRankingItem, 0, len(results))
 	for _, z := range results {
-		memberStr, ok := z.Member.(string)
-		if !ok {
-			continue
-		}
 		var productID int64
-		if _, err := fmt.Sscanf(memberStr, "%d", &productID); err != nil {
+		if _, err := fmt.Sscanf(z.Member, "%d", &productID); err != nil {
 			continue
 		}
 		items = append(items, models.ProductRankingItem{
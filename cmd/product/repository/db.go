@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"fmt"
+	"maps"
+	"productfc/kafka/idempotency"
 	"productfc/models"
+	"slices"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -18,6 +22,15 @@ func (r *ProductRepository) FindProductById(ctx context.Context, id int64) (*mod
 	return &product, nil
 }
 
+func (r *ProductRepository) FindProductsByCategoryId(ctx context.Context, categoryID int) ([]models.Product, error) {
+	var products []models.Product
+	err := r.Database.WithContext(ctx).Table("products").Where("category_id = ?", categoryID).Order("id").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
 func (r *ProductRepository) FindProductCategoryById(ctx context.Context, productCategoryID int) (*models.ProductCategory, error) {
 	var productCategory models.ProductCategory
 	err := r.Database.WithContext(ctx).Table("product_categories").Where("id = ?", productCategoryID).Last(&productCategory).Error
@@ -129,8 +142,9 @@ func (r *ProductRepository) SearchProducts(ctx context.Context, params models.Se
 	return products, int(totalCount), nil
 }
 
-func (r *ProductRepository) UpdateProductStockByProductID(ctx context.Context, productID int64, qty int) error {
-	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+func (r *ProductRepository) UpdateProductStockByProductID(ctx context.Context, productID int64, qty int) (models.StockChange, error) {
+	var change models.StockChange
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var product models.Product
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("id = ?", productID).First(&product).Error; err != nil {
@@ -139,52 +153,304 @@ func (r *ProductRepository) UpdateProductStockByProductID(ctx context.Context, p
 		if product.Stock < qty {
 			return fmt.Errorf("%w for product %d: available=%d, requested=%d", models.ErrInsufficientStock, productID, product.Stock, qty)
 		}
+		change = stockChange(product, -qty)
 		return tx.Model(&product).Update("stock", gorm.Expr("stock - ?", qty)).Error
 	})
+	return change, err
 }
 
-func (r *ProductRepository) UpdateProductStocks(ctx context.Context, items []models.ProductItem) error {
-	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, item := range items {
-			var product models.Product
-			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-				Where("id = ?", item.ProductID).First(&product).Error; err != nil {
-				return err
+// UpdateProductStocks — 주문 재고 차감과 예약 기록(부분 롤백 한도)을 한 트랜잭션으로.
+// 상품별로 수량을 합쳐 ID 순으로 한 번에 잠그므로 같은 상품을 다른 순서로 담은 주문끼리 교착되지 않음.
+// 모자란 상품이 있으면 전부 담은 *models.InsufficientStockError.
+// partial이면 있는 만큼만 라인 순서대로 예약하고 나머지는 reservation.Shortfalls (하나도 못 잡으면 오류).
+// 백오더를 허용한 상품은 설정 한도까지 음수 재고로 받고, 재고를 넘는 수량은 reservation.Backorders.
+func (r *ProductRepository) UpdateProductStocks(ctx context.Context, orderID int64, items []models.ProductItem, partial bool) (*models.StockReservation, []models.StockChange, error) {
+	lines, err := models.AssignLineIDs(items)
+	if err != nil {
+		return nil, nil, err
+	}
+	reservation := &models.StockReservation{ID: uuid.NewString(), OrderID: orderID}
+	changes := newStockChanges()
+	err = r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids, _ := aggregateItems(lines)
+		products, err := lockProducts(tx, ids)
+		if err != nil {
+			return err
+		}
+		settings, err := backorderSettings(tx, ids)
+		if err != nil {
+			return err
+		}
+
+		plan, err := planReservation(lines, products, settings, partial)
+		if err != nil {
+			return err
+		}
+		reservation.Shortfalls = plan.shortfalls
+
+		for _, product := range products {
+			qty := plan.reserve[product.ID]
+			if qty == 0 {
+				continue
+			}
+			// 잠금 아래에서는 항상 통과하지만, 잠금 없이 바뀐 재고로 한도 아래로 내려가지 않도록 조건부 갱신
+			query := tx.Model(&models.Product{}).Where("id = ?", product.ID)
+			if setting := settings[product.ID]; !setting.Allowed {
+				query = query.Where("stock >= ?", qty)
+			} else if setting.MaxQuantity > 0 {
+				query = query.Where("stock - ? >= ?", qty, -setting.MaxQuantity)
+			}
+			result := query.Update("stock", gorm.Expr("stock - ?", qty))
+			if result.Error != nil {
+				return result.Error
 			}
-			if product.Stock < item.Quantity {
-				return fmt.Errorf("%w for product %d: available=%d, requested=%d", models.ErrInsufficientStock, item.ProductID, product.Stock, item.Quantity)
+			if result.RowsAffected == 0 {
+				return &models.InsufficientStockError{Shortfalls: []models.StockShortfall{
+					{ProductID: product.ID, Requested: plan.demand[product.ID], Available: product.Stock},
+				}}
 			}
-			if err := tx.Model(&product).Update("stock", gorm.Expr("stock - ?", item.Quantity)).Error; err != nil {
+			changes.add(product, -qty)
+		}
+
+		for _, line := range plan.lines {
+			line.ReservationID = reservation.ID
+			line.OrderID = orderID
+			reservation.Lines = append(reservation.Lines, line)
+		}
+		for _, backorder := range plan.backorders {
+			backorder.ReservationID = reservation.ID
+			backorder.OrderID = orderID
+			reservation.Backorders = append(reservation.Backorders, backorder)
+		}
+		if err := tx.Create(reservation).Error; err != nil {
+			return err
+		}
+		if len(reservation.Backorders) > 0 {
+			if err := tx.Create(&reservation.Backorders).Error; err != nil {
 				return err
 			}
 		}
-		return nil
+		// 이벤트 소비 중이면 멱등성 완료도 같은 트랜잭션에 기록
+		return idempotency.CompleteInTx(ctx, tx)
 	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return reservation, changes.list(), nil
 }
 
-func (r *ProductRepository) AddProductStockByProductID(ctx context.Context, productID int64, qty int) error {
-	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var product models.Product
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("id = ?", productID).First(&product).Error; err != nil {
+// reservationPlan — 잠근 재고로 계산한 주문 예약 (DB 쓰기 전, ReservationID/OrderID는 호출자가 채움).
+type reservationPlan struct {
+	demand     map[int64]int // 상품별 주문 수량
+	reserve    map[int64]int // 상품별 차감할 수량
+	shortfalls []models.StockShortfall
+	lines      []models.StockReservationLine
+	backorders []models.StockBackorder
+}
+
+// planReservation — products(잠금 후 읽은 재고)와 백오더 설정으로 상품별 예약 수량을 정하고 라인 순서대로 배분.
+// 모자란 상품이 있으면 전부 담은 *models.InsufficientStockError (partial이면 하나라도 잡을 수 있을 때만 부분 예약).
+// 재고(0 이상 분)를 넘는 수량은 라인별 백오더.
+func planReservation(lines []models.ProductItem, products []models.Product, settings map[int64]models.BackorderSetting, partial bool) (reservationPlan, error) {
+	_, demand := aggregateItems(lines)
+	plan := reservationPlan{demand: demand, reserve: make(map[int64]int, len(products))}
+	onHand := make(map[int64]int, len(products))
+	for _, product := range products {
+		onHand[product.ID] = max(product.Stock, 0)
+		orderable, unlimited := settings[product.ID].Orderable(product.Stock)
+		if unlimited {
+			plan.reserve[product.ID] = demand[product.ID]
+			continue
+		}
+		plan.reserve[product.ID] = min(demand[product.ID], orderable)
+		if orderable < demand[product.ID] {
+			plan.shortfalls = append(plan.shortfalls, models.StockShortfall{
+				ProductID: product.ID,
+				Requested: demand[product.ID],
+				Available: orderable,
+			})
+		}
+	}
+	if len(plan.shortfalls) > 0 && (!partial || allZero(plan.reserve)) {
+		return reservationPlan{}, &models.InsufficientStockError{Shortfalls: plan.shortfalls}
+	}
+
+	// 상품별 예약 수량을 라인 순서대로 배분 (부분 충족이면 뒤 라인부터 모자라고, 재고를 넘는 분은 백오더)
+	remaining := maps.Clone(plan.reserve)
+	for _, item := range lines {
+		qty := min(item.Quantity, remaining[item.ProductID])
+		if qty == 0 {
+			continue
+		}
+		remaining[item.ProductID] -= qty
+		plan.lines = append(plan.lines, models.StockReservationLine{
+			LineID:    item.LineID,
+			ProductID: item.ProductID,
+			Quantity:  qty,
+		})
+
+		inStock := min(qty, onHand[item.ProductID])
+		onHand[item.ProductID] -= inStock
+		if backordered := qty - inStock; backordered > 0 {
+			setting := settings[item.ProductID]
+			plan.backorders = append(plan.backorders, models.StockBackorder{
+				LineID:            item.LineID,
+				ProductID:         item.ProductID,
+				Mode:              setting.Mode,
+				ExpectedRestockAt: setting.ExpectedRestockAt,
+				Quantity:          backordered,
+				Status:            models.BackorderStatusPending,
+			})
+		}
+	}
+	return plan, nil
+}
+
+func allZero(qty map[int64]int) bool {
+	for _, n := range qty {
+		if n > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ProductRepository) AddProductStockByProductID(ctx context.Context, productID int64, qty int) (models.StockChange, error) {
+	changes := newStockChanges()
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return addStocks(tx, []models.ProductItem{{ProductID: productID, Quantity: qty}}, nil, changes)
+	})
+	if err != nil {
+		return models.StockChange{}, err
+	}
+	return changes.list()[0], nil
+}
+
+func (r *ProductRepository) AddProductStocks(ctx context.Context, items []models.ProductItem) ([]models.StockChange, error) {
+	changes := newStockChanges()
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := addStocks(tx, items, nil, changes); err != nil {
 			return err
 		}
-		return tx.Model(&product).Update("stock", gorm.Expr("stock + ?", qty)).Error
+		return idempotency.CompleteInTx(ctx, tx)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return changes.list(), nil
 }
 
-func (r *ProductRepository) AddProductStocks(ctx context.Context, items []models.ProductItem) error {
-	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, item := range items {
-			var product models.Product
-			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-				Where("id = ?", item.ProductID).First(&product).Error; err != nil {
-				return err
-			}
-			if err := tx.Model(&product).Update("stock", gorm.Expr("stock + ?", item.Quantity)).Error; err != nil {
-				return err
+// addStocks — 상품별로 합쳐 ID 순으로 잠근 뒤 재고 가산하고, 대기 중인 백오더에 FIFO로 할당
+// (호출한 트랜잭션 안에서). allocatable이 nil이면 가산 수량 전부, 아니면 상품별 그 수량까지만 할당.
+func addStocks(tx *gorm.DB, items []models.ProductItem, allocatable map[int64]int, changes *stockChanges) error {
+	ids, qty := aggregateItems(items)
+	products, err := lockProducts(tx, ids)
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		if err := tx.Model(&models.Product{}).Where("id = ?", product.ID).
+			Update("stock", gorm.Expr("stock + ?", qty[product.ID])).Error; err != nil {
+			return err
+		}
+		changes.add(product, qty[product.ID])
+
+		budget := qty[product.ID]
+		if allocatable != nil {
+			budget = allocatable[product.ID]
+		}
+		if budget <= 0 {
+			continue
+		}
+		allocations, err := allocateBackorders(tx, product.ID, budget)
+		if err != nil {
+			return err
+		}
+		changes.allocate(product.ID, allocations)
+	}
+	return nil
+}
+
+// aggregateItems — 상품별 수량 합계와 정렬된 상품 ID (잠금 순서).
+func aggregateItems(items []models.ProductItem) ([]int64, map[int64]int) {
+	qty := make(map[int64]int, len(items))
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		if _, ok := qty[item.ProductID]; !ok {
+			ids = append(ids, item.ProductID)
+		}
+		qty[item.ProductID] += item.Quantity
+	}
+	slices.Sort(ids)
+	return ids, qty
+}
+
+// lockProducts — SELECT ... WHERE id IN (...) ORDER BY id FOR UPDATE 한 번으로 잠금. 없는 상품이 있으면 오류.
+func lockProducts(tx *gorm.DB, ids []int64) ([]models.Product, error) {
+	var products []models.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).Order("id").Find(&products).Error; err != nil {
+		return nil, err
+	}
+	if len(products) != len(ids) {
+		found := make(map[int64]struct{}, len(products))
+		for _, p := range products {
+			found[p.ID] = struct{}{}
+		}
+		var missing []int64
+		for _, id := range ids {
+			if _, ok := found[id]; !ok {
+				missing = append(missing, id)
 			}
 		}
-		return nil
-	})
+		return nil, fmt.Errorf("%w: %v", models.ErrProductNotFound, missing)
+	}
+	return products, nil
+}
+
+func stockChange(product models.Product, delta int) models.StockChange {
+	return models.StockChange{
+		ProductID:  product.ID,
+		CategoryID: product.CategoryID,
+		OldStock:   product.Stock,
+		NewStock:   product.Stock + delta,
+	}
+}
+
+// stockChanges — 같은 상품이 여러 줄이면 첫 재고와 최종 재고로 합침 (입력 순서 유지).
+type stockChanges struct {
+	order   []int64
+	changes map[int64]*models.StockChange
+}
+
+func newStockChanges() *stockChanges {
+	return &stockChanges{changes: make(map[int64]*models.StockChange)}
+}
+
+// add — product는 잠금 후 읽은 (이번 줄 반영 전) 재고.
+func (c *stockChanges) add(product models.Product, delta int) {
+	if change, ok := c.changes[product.ID]; ok {
+		change.NewStock = product.Stock + delta
+		return
+	}
+	change := stockChange(product, delta)
+	c.order = append(c.order, product.ID)
+	c.changes[product.ID] = &change
+}
+
+func (c *stockChanges) allocate(productID int64, allocations []models.BackorderAllocation) {
+	if change, ok := c.changes[productID]; ok {
+		change.Allocated = append(change.Allocated, allocations...)
+	}
+}
+
+func (c *stockChanges) list() []models.StockChange {
+	list := make([]models.StockChange, 0, len(c.order))
+	for _, id := range c.order {
+		list = append(list, *c.changes[id])
+	}
+	return list
 }
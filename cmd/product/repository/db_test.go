@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"productfc/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPlanReservation(t *testing.T) {
+	products := []models.Product{
+		{ID: 1, Stock: 5},  // 백오더 없음
+		{ID: 2, Stock: 1},  // 백오더 허용, 한도 3
+		{ID: 3, Stock: -2}, // 백오더 무제한
+		{ID: 4, Stock: 0},  // 백오더 없음, 품절
+	}
+	settings := map[int64]models.BackorderSetting{
+		2: {ProductID: 2, Allowed: true, Mode: models.BackorderModeBackorder, MaxQuantity: 3},
+		3: {ProductID: 3, Allowed: true, Mode: models.BackorderModeBackorder},
+	}
+	line := func(lineID, productID int64, qty int) models.StockReservationLine {
+		return models.StockReservationLine{LineID: lineID, ProductID: productID, Quantity: qty}
+	}
+	backorder := func(lineID, productID int64, qty int) models.StockBackorder {
+		return models.StockBackorder{
+			LineID: lineID, ProductID: productID, Quantity: qty,
+			Mode: models.BackorderModeBackorder, Status: models.BackorderStatusPending,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		products       []int64 // 잠근 상품 (ID 순)
+		items          []models.ProductItem
+		partial        bool
+		wantReserve    map[int64]int
+		wantLines      []models.StockReservationLine
+		wantBackorders []models.StockBackorder
+		wantShortfalls []models.StockShortfall
+		wantErr        bool
+	}{
+		{
+			name:        "in stock across lines of the same product",
+			products:    []int64{1},
+			items:       []models.ProductItem{{LineID: 1, ProductID: 1, Quantity: 2}, {LineID: 2, ProductID: 1, Quantity: 3}},
+			wantReserve: map[int64]int{1: 5},
+			wantLines:   []models.StockReservationLine{line(1, 1, 2), line(2, 1, 3)},
+		},
+		{
+			name:           "shortfall rejects the whole order",
+			products:       []int64{1, 2},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 1, Quantity: 6}, {LineID: 2, ProductID: 2, Quantity: 5}},
+			wantShortfalls: []models.StockShortfall{{ProductID: 1, Requested: 6, Available: 5}, {ProductID: 2, Requested: 5, Available: 4}},
+			wantErr:        true,
+		},
+		{
+			name:           "partial fills earlier lines first",
+			products:       []int64{1},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 1, Quantity: 2}, {LineID: 2, ProductID: 1, Quantity: 4}},
+			partial:        true,
+			wantReserve:    map[int64]int{1: 5},
+			wantLines:      []models.StockReservationLine{line(1, 1, 2), line(2, 1, 3)},
+			wantShortfalls: []models.StockShortfall{{ProductID: 1, Requested: 6, Available: 5}},
+		},
+		{
+			name:           "partial keeps other products when one is sold out",
+			products:       []int64{1, 4},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 4, Quantity: 1}, {LineID: 2, ProductID: 1, Quantity: 1}},
+			partial:        true,
+			wantReserve:    map[int64]int{1: 1, 4: 0},
+			wantLines:      []models.StockReservationLine{line(2, 1, 1)},
+			wantShortfalls: []models.StockShortfall{{ProductID: 4, Requested: 1, Available: 0}},
+		},
+		{
+			name:           "partial with nothing reservable is rejected",
+			products:       []int64{4},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 4, Quantity: 1}},
+			partial:        true,
+			wantShortfalls: []models.StockShortfall{{ProductID: 4, Requested: 1, Available: 0}},
+			wantErr:        true,
+		},
+		{
+			name:           "bounded backorder splits on-hand and backordered lines",
+			products:       []int64{2},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 2, Quantity: 1}, {LineID: 2, ProductID: 2, Quantity: 3}},
+			wantReserve:    map[int64]int{2: 4},
+			wantLines:      []models.StockReservationLine{line(1, 2, 1), line(2, 2, 3)},
+			wantBackorders: []models.StockBackorder{backorder(2, 2, 3)},
+		},
+		{
+			name:           "bounded backorder over the limit",
+			products:       []int64{2},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 2, Quantity: 5}},
+			wantShortfalls: []models.StockShortfall{{ProductID: 2, Requested: 5, Available: 4}},
+			wantErr:        true,
+		},
+		{
+			name:           "unlimited backorder on negative stock",
+			products:       []int64{3},
+			items:          []models.ProductItem{{LineID: 1, ProductID: 3, Quantity: 4}},
+			wantReserve:    map[int64]int{3: 4},
+			wantLines:      []models.StockReservationLine{line(1, 3, 4)},
+			wantBackorders: []models.StockBackorder{backorder(1, 3, 4)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var locked []models.Product
+			for _, p := range products {
+				for _, id := range tt.products {
+					if p.ID == id {
+						locked = append(locked, p)
+					}
+				}
+			}
+			plan, err := planReservation(tt.items, locked, settings, tt.partial)
+			if tt.wantErr {
+				var insufficient *models.InsufficientStockError
+				if !errors.As(err, &insufficient) {
+					t.Fatalf("err = %v, want InsufficientStockError", err)
+				}
+				if !reflect.DeepEqual(insufficient.Shortfalls, tt.wantShortfalls) {
+					t.Fatalf("shortfalls = %+v, want %+v", insufficient.Shortfalls, tt.wantShortfalls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(plan.reserve, tt.wantReserve) {
+				t.Fatalf("reserve = %v, want %v", plan.reserve, tt.wantReserve)
+			}
+			if !reflect.DeepEqual(plan.lines, tt.wantLines) {
+				t.Fatalf("lines = %+v, want %+v", plan.lines, tt.wantLines)
+			}
+			if !reflect.DeepEqual(plan.backorders, tt.wantBackorders) {
+				t.Fatalf("backorders = %+v, want %+v", plan.backorders, tt.wantBackorders)
+			}
+			if !reflect.DeepEqual(plan.shortfalls, tt.wantShortfalls) {
+				t.Fatalf("shortfalls = %+v, want %+v", plan.shortfalls, tt.wantShortfalls)
+			}
+		})
+	}
+}
+
+func newMockRepository(t *testing.T) (*ProductRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	return NewProductRepository(db, nil), mock
+}
+
+// 상품을 ID 순으로 한 번에 잠그고, 잠금 밖에서 재고가 줄어 조건부 갱신이 0행이면 롤백.
+func TestUpdateProductStocksLockOrderAndConditionalUpdate(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1,$2) ORDER BY id FOR UPDATE`)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "stock", "category_id"}).AddRow(1, 5, 1).AddRow(2, 5, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "backorder_settings" WHERE product_id IN ($1,$2) AND allowed`)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "stock"=stock - $1 WHERE id = $2 AND stock >= $3`)).
+		WithArgs(1, int64(1), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "stock"=stock - $1 WHERE id = $2 AND stock >= $3`)).
+		WithArgs(3, int64(2), 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	// 주문 라인은 상품 2가 먼저지만 잠금은 ID 순
+	items := []models.ProductItem{{ProductID: 2, Quantity: 3}, {ProductID: 1, Quantity: 1}}
+	_, _, err := repo.UpdateProductStocks(context.Background(), 1001, items, false)
+
+	var insufficient *models.InsufficientStockError
+	if !errors.As(err, &insufficient) {
+		t.Fatalf("err = %v, want InsufficientStockError", err)
+	}
+	want := []models.StockShortfall{{ProductID: 2, Requested: 3, Available: 5}}
+	if !reflect.DeepEqual(insufficient.Shortfalls, want) {
+		t.Fatalf("shortfalls = %+v, want %+v", insufficient.Shortfalls, want)
+	}
+}
+
+func TestLockProductsMissing(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1,$2,$3) ORDER BY id FOR UPDATE`)).
+		WithArgs(int64(1), int64(2), int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(2, 1))
+
+	_, err := lockProducts(repo.Database, []int64{1, 2, 3})
+	if !errors.Is(err, models.ErrProductNotFound) {
+		t.Fatalf("err = %v, want ErrProductNotFound", err)
+	}
+}
+
+func TestAggregateItemsSortsLockOrder(t *testing.T) {
+	ids, qty := aggregateItems([]models.ProductItem{
+		{ProductID: 3, Quantity: 1}, {ProductID: 1, Quantity: 2}, {ProductID: 3, Quantity: 4},
+	})
+	if !reflect.DeepEqual(ids, []int64{1, 3}) {
+		t.Fatalf("ids = %v, want [1 3]", ids)
+	}
+	if !reflect.DeepEqual(qty, map[int64]int{1: 2, 3: 5}) {
+		t.Fatalf("qty = %v, want map[1:2 3:5]", qty)
+	}
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"productfc/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreatePurchaseOrder — 발주와 라인을 한 트랜잭션으로 저장. 없는 상품이 있으면 models.ErrProductNotFound.
+func (r *ProductRepository) CreatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) error {
+	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make(map[int64]struct{}, len(order.Lines))
+		for _, line := range order.Lines {
+			ids[line.ProductID] = struct{}{}
+		}
+		var found []int64
+		if err := tx.Model(&models.Product{}).Where("id IN ?", keys(ids)).Pluck("id", &found).Error; err != nil {
+			return err
+		}
+		for _, id := range found {
+			delete(ids, id)
+		}
+		if len(ids) > 0 {
+			return fmt.Errorf("%w: %v", models.ErrProductNotFound, keys(ids))
+		}
+		return tx.Create(order).Error
+	})
+}
+
+func (r *ProductRepository) FindPurchaseOrder(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := r.Database.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&order, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ReceivePurchaseOrder — 라인별 입고 수량을 기록하고 AddProductStocks와 같은 경로(상품 잠금 + 백오더 할당)로 재고 가산.
+// receipts가 비어 있으면 남은 수량 전부. 남은 수량을 넘거나 다른 발주의 라인이면 models.ErrInvalidReceipt.
+func (r *ProductRepository) ReceivePurchaseOrder(ctx context.Context, id int64, receipts []models.ReceivePurchaseOrderLine, receivedBy int64) (*models.PurchaseOrder, []models.StockChange, error) {
+	var order models.PurchaseOrder
+	changes := newStockChanges()
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return models.ErrPurchaseOrderNotFound
+			}
+			return err
+		}
+		if order.Status == models.PurchaseOrderStatusReceived || order.Status == models.PurchaseOrderStatusCancelled {
+			return fmt.Errorf("%w: %d is %s", models.ErrPurchaseOrderClosed, order.ID, order.Status)
+		}
+		if err := tx.Where("purchase_order_id = ?", order.ID).Order("id").Find(&order.Lines).Error; err != nil {
+			return err
+		}
+
+		received, err := planReceipt(order.Lines, receipts)
+		if err != nil {
+			return fmt.Errorf("purchase order %d: %w", order.ID, err)
+		}
+
+		var items []models.ProductItem
+		var rows []models.PurchaseOrderReceipt
+		for i, qty := range received {
+			if qty == 0 {
+				continue
+			}
+			line := &order.Lines[i]
+			line.ReceivedQuantity += qty
+			if err := tx.Model(line).Update("received_quantity", line.ReceivedQuantity).Error; err != nil {
+				return err
+			}
+			items = append(items, models.ProductItem{ProductID: line.ProductID, Quantity: qty})
+			rows = append(rows, models.PurchaseOrderReceipt{
+				PurchaseOrderID: order.ID,
+				LineID:          line.ID,
+				ProductID:       line.ProductID,
+				Quantity:        qty,
+				ReceivedBy:      receivedBy,
+			})
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("%w: nothing left to receive", models.ErrInvalidReceipt)
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		if err := addStocks(tx, items, nil, changes); err != nil {
+			return err
+		}
+
+		order.Status = models.PurchaseOrderStatusReceived
+		for _, line := range order.Lines {
+			if line.Remaining() > 0 {
+				order.Status = models.PurchaseOrderStatusPartiallyReceived
+				break
+			}
+		}
+		order.UpdatedAt = time.Now()
+		return tx.Model(&order).Updates(map[string]any{"status": order.Status, "updated_at": order.UpdatedAt}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &order, changes.list(), nil
+}
+
+// planReceipt — 입고 요청을 발주 라인에 배분 (lines와 같은 인덱스의 입고 수량).
+func planReceipt(lines []models.PurchaseOrderLine, receipts []models.ReceivePurchaseOrderLine) ([]int, error) {
+	plan := make([]int, len(lines))
+	if len(receipts) == 0 {
+		for i, line := range lines {
+			plan[i] = line.Remaining()
+		}
+		return plan, nil
+	}
+
+	index := make(map[int64]int, len(lines))
+	for i, line := range lines {
+		index[line.ID] = i
+	}
+	for _, receipt := range receipts {
+		i, ok := index[receipt.LineID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown line %d", models.ErrInvalidReceipt, receipt.LineID)
+		}
+		plan[i] += receipt.Quantity
+		if plan[i] > lines[i].Remaining() {
+			return nil, fmt.Errorf("%w: line %d received=%d, remaining=%d", models.ErrInvalidReceipt, receipt.LineID, plan[i], lines[i].Remaining())
+		}
+	}
+	return plan, nil
+}
+
+// CancelPurchaseOrder — 남은 수량을 더 입고하지 않음 (이미 입고된 수량은 그대로).
+func (r *ProductRepository) CancelPurchaseOrder(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return models.ErrPurchaseOrderNotFound
+			}
+			return err
+		}
+		if order.Status == models.PurchaseOrderStatusReceived || order.Status == models.PurchaseOrderStatusCancelled {
+			return fmt.Errorf("%w: %d is %s", models.ErrPurchaseOrderClosed, order.ID, order.Status)
+		}
+		order.Status = models.PurchaseOrderStatusCancelled
+		order.UpdatedAt = time.Now()
+		if err := tx.Model(&order).Updates(map[string]any{"status": order.Status, "updated_at": order.UpdatedAt}).Error; err != nil {
+			return err
+		}
+		return tx.Where("purchase_order_id = ?", order.ID).Order("id").Find(&order.Lines).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindIncomingStocks — 열린 발주(open/partially_received)의 상품별 미입고 수량과 아직 지나지 않은 가장 이른 입고 예정일.
+// 예정일이 지난 라인은 수량에는 포함하지만 입고 예정일에서는 제외 (모두 지났으면 예정일 없음).
+func (r *ProductRepository) FindIncomingStocks(ctx context.Context, productIDs []int64) (map[int64]models.ProductIncoming, error) {
+	var rows []struct {
+		ProductID  int64
+		Quantity   int
+		RestockETA *time.Time
+	}
+	err := r.Database.WithContext(ctx).Table("purchase_order_lines AS l").
+		Select("l.product_id, SUM(l.quantity - l.received_quantity) AS quantity, "+
+			"MIN(l.expected_at) FILTER (WHERE l.expected_at > ?) AS restock_eta", time.Now()).
+		Joins("JOIN purchase_orders AS po ON po.id = l.purchase_order_id").
+		Where("po.status IN ?", []string{models.PurchaseOrderStatusOpen, models.PurchaseOrderStatusPartiallyReceived}).
+		Where("l.product_id IN ? AND l.quantity > l.received_quantity", productIDs).
+		Group("l.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	incoming := make(map[int64]models.ProductIncoming, len(rows))
+	for _, row := range rows {
+		incoming[row.ProductID] = models.ProductIncoming{ProductID: row.ProductID, Quantity: row.Quantity, RestockETA: row.RestockETA}
+	}
+	return incoming, nil
+}
+
+func keys(set map[int64]struct{}) []int64 {
+	out := make([]int64, 0, len(set))
+	for id := range set {
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"productfc/models"
+)
+
+func TestPlanReceipt(t *testing.T) {
+	lines := []models.PurchaseOrderLine{
+		{ID: 10, ProductID: 1, Quantity: 5, ReceivedQuantity: 2},
+		{ID: 11, ProductID: 2, Quantity: 3},
+		{ID: 12, ProductID: 3, Quantity: 4, ReceivedQuantity: 4},
+	}
+	tests := []struct {
+		name     string
+		receipts []models.ReceivePurchaseOrderLine
+		want     []int
+		wantErr  error
+	}{
+		{
+			name: "empty receipts receive everything remaining",
+			want: []int{3, 3, 0},
+		},
+		{
+			name:     "partial receipt",
+			receipts: []models.ReceivePurchaseOrderLine{{LineID: 11, Quantity: 2}},
+			want:     []int{0, 2, 0},
+		},
+		{
+			name:     "same line repeated is summed",
+			receipts: []models.ReceivePurchaseOrderLine{{LineID: 10, Quantity: 1}, {LineID: 10, Quantity: 2}},
+			want:     []int{3, 0, 0},
+		},
+		{
+			name:     "same line repeated beyond remaining",
+			receipts: []models.ReceivePurchaseOrderLine{{LineID: 10, Quantity: 2}, {LineID: 10, Quantity: 2}},
+			wantErr:  models.ErrInvalidReceipt,
+		},
+		{
+			name:     "over-receipt",
+			receipts: []models.ReceivePurchaseOrderLine{{LineID: 11, Quantity: 4}},
+			wantErr:  models.ErrInvalidReceipt,
+		},
+		{
+			name:     "fully received line",
+			receipts: []models.ReceivePurchaseOrderLine{{LineID: 12, Quantity: 1}},
+			wantErr:  models.ErrInvalidReceipt,
+		},
+		{
+			name:     "unknown line",
+			receipts: []models.ReceivePurchaseOrderLine{{LineID: 99, Quantity: 1}},
+			wantErr:  models.ErrInvalidReceipt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planReceipt(lines, tt.receipts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("plan = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
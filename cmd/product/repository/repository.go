@@ -1,15 +1,16 @@
 package repository
 
 import (
-	redis "github.com/redis/go-redis/v9"
+	"productfc/infrastructure/cache"
+
 	"gorm.io/gorm"
 )
 
 type ProductRepository struct {
 	Database *gorm.DB
-	Redis    *redis.Client
+	Cache    cache.Cache
 }
 
-func NewProductRepository(db *gorm.DB, redis *redis.Client) *ProductRepository {
-	return &ProductRepository{Database: db, Redis: redis}
+func NewProductRepository(db *gorm.DB, cache cache.Cache) *ProductRepository {
+	return &ProductRepository{Database: db, Cache: cache}
 }
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"productfc/infrastructure/log"
+	"productfc/kafka/idempotency"
+	"productfc/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RestoreReservedStocks — 롤백 이벤트의 라인/수량만큼 예약을 복원하고 재고 가산.
+// 예약 기록이 없는 주문(예약 기록 도입 전 차감분)은 이전처럼 목록 그대로 복원.
+// 한 라인이라도 한도를 넘으면 아무것도 복원하지 않음.
+func (r *ProductRepository) RestoreReservedStocks(ctx context.Context, event models.ProductStockRollbackEvent) ([]models.StockChange, error) {
+	changes := newStockChanges()
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", event.OrderID)
+		if event.ReservationID != "" {
+			query = query.Where("reservation_id = ?", event.ReservationID)
+		}
+		var lines []models.StockReservationLine
+		if err := query.Order("id").Find(&lines).Error; err != nil {
+			return err
+		}
+
+		restore := event.Products
+		var allocatable map[int64]int
+		if len(lines) == 0 {
+			if event.ReservationID != "" {
+				return fmt.Errorf("%w: order %d reservation %s", models.ErrReservationNotFound, event.OrderID, event.ReservationID)
+			}
+			log.Logger.Warn().Int64("order_id", event.OrderID).Msg("No stock reservation recorded for order - restoring rollback items as-is")
+		} else {
+			plan, err := planRestore(lines, event.Products)
+			if err != nil {
+				return fmt.Errorf("order %d: %w", event.OrderID, err)
+			}
+			restore = restore[:0:0]
+			allocatable = make(map[int64]int)
+			for i, qty := range plan {
+				if qty == 0 {
+					continue
+				}
+				if err := tx.Model(&lines[i]).
+					Update("restored_quantity", gorm.Expr("restored_quantity + ?", qty)).Error; err != nil {
+					return err
+				}
+				// 아직 입고되지 않은 백오더분부터 취소 — 그 수량은 실제 입고가 아니므로 다른 백오더에 할당하지 않음
+				cancelled, err := cancelBackorder(tx, lines[i], qty)
+				if err != nil {
+					return err
+				}
+				allocatable[lines[i].ProductID] += qty - cancelled
+				restore = append(restore, models.ProductItem{ProductID: lines[i].ProductID, Quantity: qty})
+			}
+		}
+
+		if err := addStocks(tx, restore, allocatable, changes); err != nil {
+			return err
+		}
+		return idempotency.CompleteInTx(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes.list(), nil
+}
+
+// FindOrderReservation — 주문의 첫 재고 예약 (order.created로 만든 예약)과 라인. 없으면 models.ErrReservationNotFound.
+func (r *ProductRepository) FindOrderReservation(ctx context.Context, orderID int64) (*models.StockReservation, error) {
+	var reservation models.StockReservation
+	err := r.Database.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("order_id = ?", orderID).Order("created_at, id").First(&reservation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: order %d", models.ErrReservationNotFound, orderID)
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// planRestore — 롤백 라인을 예약 라인에 배분 (lines와 같은 인덱스의 복원 수량).
+// line_id가 있으면 그 라인만, 없으면 같은 상품 라인에 예약 순서대로 남은 수량만큼.
+func planRestore(lines []models.StockReservationLine, items []models.ProductItem) ([]int, error) {
+	plan := make([]int, len(lines))
+	seen := make(map[int64]struct{}, len(items))
+	for _, item := range items {
+		if item.LineID != 0 {
+			if _, ok := seen[item.LineID]; ok {
+				return nil, fmt.Errorf("%w: duplicate line_id %d", models.ErrInvalidRollback, item.LineID)
+			}
+			seen[item.LineID] = struct{}{}
+		}
+
+		remaining, found := item.Quantity, false
+		for i, line := range lines {
+			if item.LineID != 0 && line.LineID != item.LineID {
+				continue
+			}
+			if line.ProductID != item.ProductID {
+				if item.LineID != 0 {
+					return nil, fmt.Errorf("%w: line %d is product %d, not %d", models.ErrInvalidRollback, line.LineID, line.ProductID, item.ProductID)
+				}
+				continue
+			}
+			found = true
+			qty := min(remaining, line.Remaining()-plan[i])
+			if qty <= 0 {
+				continue
+			}
+			plan[i] += qty
+			remaining -= qty
+			if remaining == 0 {
+				break
+			}
+		}
+
+		switch {
+		case !found && item.LineID != 0:
+			return nil, fmt.Errorf("%w: unknown line %d", models.ErrInvalidRollback, item.LineID)
+		case !found:
+			return nil, fmt.Errorf("%w: product %d was not reserved", models.ErrInvalidRollback, item.ProductID)
+		case remaining > 0:
+			return nil, fmt.Errorf("%w: product %d line %d requested=%d, restorable=%d",
+				models.ErrRestoreExceedsReserved, item.ProductID, item.LineID, item.Quantity, item.Quantity-remaining)
+		}
+	}
+	return plan, nil
+}
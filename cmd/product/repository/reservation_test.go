@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"productfc/models"
+)
+
+func TestPlanRestore(t *testing.T) {
+	// 상품 1은 두 라인(1, 3), 라인 3은 이미 1개 복원
+	lines := []models.StockReservationLine{
+		{LineID: 1, ProductID: 1, Quantity: 2},
+		{LineID: 2, ProductID: 2, Quantity: 1},
+		{LineID: 3, ProductID: 1, Quantity: 3, RestoredQuantity: 1},
+	}
+	tests := []struct {
+		name    string
+		items   []models.ProductItem
+		want    []int
+		wantErr error
+	}{
+		{
+			name:  "by line id",
+			items: []models.ProductItem{{ProductID: 1, LineID: 3, Quantity: 2}},
+			want:  []int{0, 0, 2},
+		},
+		{
+			name:  "by product fills lines in reservation order",
+			items: []models.ProductItem{{ProductID: 1, Quantity: 3}},
+			want:  []int{2, 0, 1},
+		},
+		{
+			name:  "whole order",
+			items: []models.ProductItem{{ProductID: 1, Quantity: 4}, {ProductID: 2, Quantity: 1}},
+			want:  []int{2, 1, 2},
+		},
+		{
+			name:  "line id and product mixed",
+			items: []models.ProductItem{{ProductID: 1, LineID: 1, Quantity: 2}, {ProductID: 1, Quantity: 2}},
+			want:  []int{2, 0, 2},
+		},
+		{
+			name:    "product exceeds reserved",
+			items:   []models.ProductItem{{ProductID: 1, Quantity: 5}},
+			wantErr: models.ErrRestoreExceedsReserved,
+		},
+		{
+			name:    "line exceeds remaining",
+			items:   []models.ProductItem{{ProductID: 1, LineID: 3, Quantity: 3}},
+			wantErr: models.ErrRestoreExceedsReserved,
+		},
+		{
+			name:    "same line restored twice in one request",
+			items:   []models.ProductItem{{ProductID: 2, LineID: 2, Quantity: 1}, {ProductID: 2, LineID: 2, Quantity: 1}},
+			wantErr: models.ErrInvalidRollback,
+		},
+		{
+			name:    "unknown line",
+			items:   []models.ProductItem{{ProductID: 1, LineID: 9, Quantity: 1}},
+			wantErr: models.ErrInvalidRollback,
+		},
+		{
+			name:    "line of another product",
+			items:   []models.ProductItem{{ProductID: 2, LineID: 1, Quantity: 1}},
+			wantErr: models.ErrInvalidRollback,
+		},
+		{
+			name:    "product not reserved",
+			items:   []models.ProductItem{{ProductID: 7, Quantity: 1}},
+			wantErr: models.ErrInvalidRollback,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planRestore(lines, tt.items)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("plan = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
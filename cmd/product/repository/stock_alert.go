@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"productfc/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindLowStockThreshold — 상품 → 카테고리 순으로 임계치 조회. 둘 다 없으면 found=false.
+func (r *ProductRepository) FindLowStockThreshold(ctx context.Context, productID int64, categoryID int) (int, bool, error) {
+	var threshold models.StockThreshold
+	err := r.Database.WithContext(ctx).Table("stock_thresholds").
+		Where("product_id = ? OR category_id = ?", productID, categoryID).
+		Order("product_id IS NULL").
+		First(&threshold).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return threshold.LowStockThreshold, true, nil
+}
+
+func (r *ProductRepository) ProductExists(ctx context.Context, productID int64) (bool, error) {
+	var count int64
+	err := r.Database.WithContext(ctx).Table("products").Where("id = ?", productID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *ProductRepository) UpsertProductStockThreshold(ctx context.Context, productID int64, lowStockThreshold int) (*models.StockThreshold, error) {
+	threshold := &models.StockThreshold{ProductID: &productID, LowStockThreshold: lowStockThreshold, UpdatedAt: time.Now()}
+	return r.upsertStockThreshold(ctx, threshold, "product_id")
+}
+
+func (r *ProductRepository) UpsertCategoryStockThreshold(ctx context.Context, categoryID int, lowStockThreshold int) (*models.StockThreshold, error) {
+	threshold := &models.StockThreshold{CategoryID: &categoryID, LowStockThreshold: lowStockThreshold, UpdatedAt: time.Now()}
+	return r.upsertStockThreshold(ctx, threshold, "category_id")
+}
+
+func (r *ProductRepository) upsertStockThreshold(ctx context.Context, threshold *models.StockThreshold, column string) (*models.StockThreshold, error) {
+	err := r.Database.WithContext(ctx).Table("stock_thresholds").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: column}},
+			DoUpdates: clause.AssignmentColumns([]string{"low_stock_threshold", "updated_at"}),
+		}).
+		Create(threshold).Error
+	if err != nil {
+		return nil, err
+	}
+	return threshold, nil
+}
+
+// TransitionStockAlertLevel — 상품의 알림 수준이 level과 다르면 publish(이전 수준)로 알린 뒤 level로 기록.
+// 이미 같은 수준이면 changed=false (중복 알림 방지). 상태가 없으면 initial로 시작.
+// 행 잠금으로 여러 인스턴스가 같은 전이를 동시에 알리지 않게 하고, publish가 실패하면 기록하지 않음 (다음 변경에서 다시 알림).
+func (r *ProductRepository) TransitionStockAlertLevel(ctx context.Context, productID int64, initial, level string, publish func(previous string) error) (changed bool, err error) {
+	err = r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seed := models.StockAlertState{ProductID: productID, Level: initial, UpdatedAt: time.Now()}
+		if err := tx.Table("stock_alert_states").Clauses(clause.OnConflict{DoNothing: true}).Create(&seed).Error; err != nil {
+			return err
+		}
+
+		var state models.StockAlertState
+		if err := tx.Table("stock_alert_states").Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ?", productID).First(&state).Error; err != nil {
+			return err
+		}
+		if state.Level == level {
+			return nil
+		}
+		if err := publish(state.Level); err != nil {
+			return err
+		}
+		changed = true
+		return tx.Table("stock_alert_states").Where("product_id = ?", productID).
+			Updates(map[string]interface{}{"level": level, "updated_at": time.Now()}).Error
+	})
+	return changed && err == nil, err
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"productfc/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestTransitionStockAlertLevel(t *testing.T) {
+	errPublish := errors.New("broker unavailable")
+	tests := []struct {
+		name         string
+		stored       string
+		level        string
+		publishErr   error
+		wantPublish  bool
+		wantRecorded bool
+		wantChanged  bool
+	}{
+		{name: "same level, nothing to publish", stored: models.StockLevelLow, level: models.StockLevelLow},
+		{name: "published, level recorded", stored: models.StockLevelLow, level: models.StockLevelOut, wantPublish: true, wantRecorded: true, wantChanged: true},
+		{name: "publish failed, level not recorded", stored: models.StockLevelLow, level: models.StockLevelOut, publishErr: errPublish, wantPublish: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_alert_states"`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_alert_states" WHERE product_id = $1`)).
+				WithArgs(int64(1), 1).
+				WillReturnRows(sqlmock.NewRows([]string{"product_id", "level"}).AddRow(1, tt.stored))
+			if tt.wantRecorded {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_alert_states" SET "level"=$1`)).
+					WithArgs(tt.level, sqlmock.AnyArg(), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			if tt.publishErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			var published []string
+			changed, err := repo.TransitionStockAlertLevel(context.Background(), 1, models.StockLevelOK, tt.level, func(previous string) error {
+				published = append(published, previous)
+				return tt.publishErr
+			})
+			if !errors.Is(err, tt.publishErr) {
+				t.Fatalf("err = %v, want %v", err, tt.publishErr)
+			}
+			if changed != tt.wantChanged {
+				t.Fatalf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got := len(published) > 0; got != tt.wantPublish {
+				t.Fatalf("published = %v, want publish %v", published, tt.wantPublish)
+			}
+			if tt.wantPublish && published[0] != tt.stored {
+				t.Fatalf("publish previous = %q, want stored level %q", published[0], tt.stored)
+			}
+		})
+	}
+}
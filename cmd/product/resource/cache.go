@@ -0,0 +1,56 @@
+package resource
+
+import (
+	"context"
+	"productfc/config"
+	"productfc/infrastructure/cache"
+	"productfc/infrastructure/log"
+)
+
+var Cache cache.Cache
+var CacheDriver string
+
+// InitCache — cache.driver 설정에 따라 Redis(circuit breaker 포함) 또는 인메모리 캐시를 초기화.
+func InitCache(cfg config.CacheConfig, redisCfg config.RedisConfig) cache.Cache {
+	switch cfg.Driver {
+	case cache.DriverMemory:
+		log.Logger.Warn().Msg("Cache driver is memory - running without Redis (cache is not shared between instances)")
+		CacheDriver = cache.DriverMemory
+		Cache = cache.NewMemoryCache()
+	case "", cache.DriverRedis:
+		CacheDriver = cache.DriverRedis
+		Cache = cache.NewCircuitBreaker(
+			cache.NewRedisCache(InitRedis(redisCfg)),
+			cfg.BreakerFailureThreshold,
+			cfg.BreakerOpenTimeout,
+		)
+	default:
+		log.Logger.Fatal().Str("driver", cfg.Driver).Msg("Unknown cache driver")
+	}
+	return Cache
+}
+
+// CacheHealth — /health용 캐시 상태. Ping이 breaker를 거치므로 헬스체크가 half-open probe 역할도 함.
+// memory 드라이버이거나 Redis 호출이 실패/차단되면 degraded=true.
+func CacheHealth(ctx context.Context) (bool, map[string]interface{}) {
+	detail := map[string]interface{}{"driver": CacheDriver}
+	if Cache == nil {
+		detail["status"] = "not_initialized"
+		return true, detail
+	}
+
+	degraded := CacheDriver == cache.DriverMemory
+	if err := Cache.Ping(ctx); err != nil {
+		degraded = true
+		detail["error"] = err.Error()
+	}
+	if breaker, ok := Cache.(*cache.CircuitBreaker); ok {
+		detail["breaker"] = breaker.State()
+	}
+	if degraded {
+		detail["status"] = "degraded"
+	} else {
+		detail["status"] = "ok"
+	}
+	return degraded, detail
+}
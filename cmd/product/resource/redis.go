@@ -13,6 +13,7 @@ import (
 var RedisClient *redis.Client
 var RedisMonitor *redismonitor.Monitor
 
+// InitRedis — 연결 실패 시에도 기동은 계속 (circuit breaker가 DB 폴백, 복구되면 자동 재연결).
 func InitRedis(cfg config.RedisConfig) *redis.Client {
 	RedisClient = redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
@@ -22,7 +23,8 @@ func InitRedis(cfg config.RedisConfig) *redis.Client {
 	ctx := context.Background()
 	res, err := RedisClient.Ping(ctx).Result()
 	if err != nil {
-		log.Logger.Fatal().Err(err).Msg("Failed to connect Redis")
+		log.Logger.Warn().Err(err).Msg("Failed to connect Redis - starting in degraded mode")
+		return RedisClient
 	}
 
 	log.Logger.Info().Msgf("Redis connected: %s", res)
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"productfc/models"
+
+	"github.com/google/uuid"
+)
+
+// AdjustProductStock — 관리자 상대 조정. damage는 줄이는 조정만, receipt는 늘리는 조정만 허용.
+func (s *ProductService) AdjustProductStock(ctx context.Context, productID int64, req models.StockAdjustmentRequest, adjustedBy int64) (*models.StockAdjustment, error) {
+	switch {
+	case req.Reason == models.StockAdjustReasonDamage && req.Delta > 0:
+		return nil, fmt.Errorf("%w: damage must decrease stock", models.ErrInvalidAdjustment)
+	case req.Reason == models.StockAdjustReasonReceipt && req.Delta < 0:
+		return nil, fmt.Errorf("%w: receipt must increase stock", models.ErrInvalidAdjustment)
+	}
+
+	adjustment := &models.StockAdjustment{
+		ProductID:  productID,
+		Reason:     req.Reason,
+		Delta:      req.Delta,
+		Note:       req.Note,
+		AdjustedBy: adjustedBy,
+	}
+	change, err := s.ProductRepo.AdjustProductStock(ctx, adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProductCaches([]models.ProductItem{{ProductID: productID}}, "Failed to invalidate product cache after stock adjustment")
+	s.publishStockChanges(ctx, models.StockChangeAdjusted, []models.StockChange{change})
+	return adjustment, nil
+}
+
+// ApplyStocktake — 실사 수량 일괄 반영. 한 상품이라도 없으면 아무것도 반영하지 않음.
+func (s *ProductService) ApplyStocktake(ctx context.Context, req models.StocktakeRequest, adjustedBy int64) (*models.StocktakeResult, error) {
+	seen := make(map[int64]struct{}, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := seen[item.ProductID]; ok {
+			return nil, fmt.Errorf("%w: duplicate product_id %d", models.ErrInvalidStocktake, item.ProductID)
+		}
+		seen[item.ProductID] = struct{}{}
+	}
+
+	stocktakeID := uuid.NewString()
+	variances, changes, err := s.ProductRepo.ApplyStocktake(ctx, req.Items, models.StockAdjustment{
+		Reason:      models.StockAdjustReasonStocktake,
+		Note:        req.Note,
+		StocktakeID: stocktakeID,
+		AdjustedBy:  adjustedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.StocktakeResult{StocktakeID: stocktakeID, Items: variances}
+	items := make([]models.ProductItem, 0, len(variances))
+	for _, v := range variances {
+		result.TotalVariance += v.Variance
+		if v.Variance != 0 {
+			result.Mismatched++
+			items = append(items, models.ProductItem{ProductID: v.ProductID})
+		}
+	}
+
+	s.invalidateProductCaches(items, "Failed to invalidate product cache after stocktake")
+	s.publishStockChanges(ctx, models.StockChangeStocktake, changes)
+	return result, nil
+}
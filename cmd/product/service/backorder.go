@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"productfc/infrastructure/log"
+	kafkapkg "productfc/kafka"
+	"productfc/models"
+	"time"
+)
+
+func (s *ProductService) GetBackorderSetting(ctx context.Context, productID int64) (*models.BackorderSetting, error) {
+	exists, err := s.ProductRepo.ProductExists(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrProductNotFound
+	}
+	return s.ProductRepo.FindBackorderSetting(ctx, productID)
+}
+
+func (s *ProductService) SetBackorderSetting(ctx context.Context, productID int64, req models.BackorderSettingRequest) (*models.BackorderSetting, error) {
+	exists, err := s.ProductRepo.ProductExists(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrProductNotFound
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = models.BackorderModeBackorder
+	}
+	return s.ProductRepo.UpsertBackorderSetting(ctx, &models.BackorderSetting{
+		ProductID:         productID,
+		Allowed:           req.Allowed,
+		Mode:              mode,
+		MaxQuantity:       req.MaxQuantity,
+		ExpectedRestockAt: req.ExpectedRestockAt,
+	})
+}
+
+// publishBackordered — 재고를 넘어 음수 재고로 받은 라인이 있으면 stock.backordered 발행.
+func (s *ProductService) publishBackordered(ctx context.Context, reservation *models.StockReservation) {
+	if s.Events == nil || len(reservation.Backorders) == 0 {
+		return
+	}
+	items := make([]models.BackorderItem, 0, len(reservation.Backorders))
+	for _, backorder := range reservation.Backorders {
+		items = append(items, backorderItem(backorder, backorder.Quantity))
+	}
+	s.publishBackorderEvent(ctx, models.StockEventBackordered, reservation.OrderID, reservation.ID, items)
+}
+
+// publishBackorderAllocations — 입고분이 할당된 백오더를 주문(예약)별로 묶어 stock.backorder_allocated 발행.
+func (s *ProductService) publishBackorderAllocations(ctx context.Context, changes []models.StockChange) {
+	if s.Events == nil {
+		return
+	}
+	type orderKey struct {
+		orderID       int64
+		reservationID string
+	}
+	var order []orderKey
+	grouped := make(map[orderKey][]models.BackorderItem)
+	for _, change := range changes {
+		for _, allocation := range change.Allocated {
+			key := orderKey{allocation.Backorder.OrderID, allocation.Backorder.ReservationID}
+			if _, ok := grouped[key]; !ok {
+				order = append(order, key)
+			}
+			grouped[key] = append(grouped[key], backorderItem(allocation.Backorder, allocation.Quantity))
+		}
+	}
+	for _, key := range order {
+		s.publishBackorderEvent(ctx, models.StockEventBackorderAllocated, key.orderID, key.reservationID, grouped[key])
+	}
+}
+
+func (s *ProductService) publishBackorderEvent(ctx context.Context, eventType string, orderID int64, reservationID string, items []models.BackorderItem) {
+	event := models.StockBackorderEvent{
+		SchemaVersion: kafkapkg.SchemaVersionStockEvent,
+		EventType:     eventType,
+		OrderID:       orderID,
+		ReservationID: reservationID,
+		Items:         items,
+		EventTime:     time.Now(),
+	}
+	if err := s.Events.PublishStockBackorder(ctx, event); err != nil {
+		log.Logger.Error().Err(err).Str("event_type", eventType).Int64("order_id", orderID).Msg("Failed to publish stock backorder event")
+	}
+}
+
+func backorderItem(backorder models.StockBackorder, qty int) models.BackorderItem {
+	return models.BackorderItem{
+		ProductID:         backorder.ProductID,
+		LineID:            backorder.LineID,
+		Quantity:          qty,
+		Remaining:         backorder.Pending(),
+		Mode:              backorder.Mode,
+		ExpectedRestockAt: backorder.ExpectedRestockAt,
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"productfc/infrastructure/log"
+	kafkapkg "productfc/kafka"
+	"productfc/models"
+	"time"
+)
+
+// EventPublisher — 상품/카테고리 변경, 재고 변경/수준, 백오더 이벤트 발행 (kafka.Producer).
+type EventPublisher interface {
+	PublishProductEvent(ctx context.Context, event models.ProductChangedEvent) error
+	PublishCategoryEvent(ctx context.Context, event models.CategoryChangedEvent) error
+	PublishStockChanged(ctx context.Context, event models.StockChangedEvent) error
+	PublishStockLevel(ctx context.Context, event models.StockLevelEvent) error
+	PublishStockBackorder(ctx context.Context, event models.StockBackorderEvent) error
+}
+
+// publishProductEvent — DB 변경은 이미 끝났으므로 발행 실패는 요청 실패로 돌리지 않고 로그만.
+func (s *ProductService) publishProductEvent(ctx context.Context, eventType string, productID int64, before, after *models.Product) {
+	if s.Events == nil {
+		return
+	}
+	event := models.ProductChangedEvent{
+		SchemaVersion: kafkapkg.SchemaVersionCatalogEvent,
+		EventType:     eventType,
+		ProductID:     productID,
+		Before:        before,
+		After:         after,
+		EventTime:     time.Now(),
+	}
+	if err := s.Events.PublishProductEvent(ctx, event); err != nil {
+		log.Logger.Error().Err(err).Str("event_type", eventType).Int64("product_id", productID).Msg("Failed to publish product event")
+	}
+}
+
+func (s *ProductService) publishCategoryEvent(ctx context.Context, eventType string, categoryID int, before, after *models.ProductCategory) {
+	if s.Events == nil {
+		return
+	}
+	event := models.CategoryChangedEvent{
+		SchemaVersion: kafkapkg.SchemaVersionCatalogEvent,
+		EventType:     eventType,
+		CategoryID:    categoryID,
+		Before:        before,
+		After:         after,
+		EventTime:     time.Now(),
+	}
+	if err := s.Events.PublishCategoryEvent(ctx, event); err != nil {
+		log.Logger.Error().Err(err).Str("event_type", eventType).Int("category_id", categoryID).Msg("Failed to publish category event")
+	}
+}
+
+// findProductForEvent — 이벤트 before/after 스냅샷용 조회. 없거나 실패하면 nil.
+func (s *ProductService) findProductForEvent(ctx context.Context, id int64) *models.Product {
+	if s.Events == nil {
+		return nil
+	}
+	product, err := s.ProductRepo.FindProductById(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return product
+}
+
+func (s *ProductService) findCategoryForEvent(ctx context.Context, id int) *models.ProductCategory {
+	if s.Events == nil {
+		return nil
+	}
+	category, err := s.ProductRepo.FindProductCategoryById(ctx, id)
+	if err != nil || category.ID == 0 {
+		return nil
+	}
+	return category
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"strconv"
+
+	"productfc/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	stockRejectedDemand = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "stock",
+			Name:      "rejected_demand_units_total",
+			Help:      "Ordered units that could not be reserved for lack of stock, by product",
+		},
+		[]string{"product_id"},
+	)
+	stockShortfalls = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "stock",
+			Name:      "shortfalls_total",
+			Help:      "Orders that were rejected or partially filled for lack of stock, by product",
+		},
+		[]string{"product_id"},
+	)
+)
+
+// recordRejectedDemand — 주문이 원했지만 재고가 없어 잡지 못한 수량 (머천다이징용 미충족 수요).
+func recordRejectedDemand(shortfalls []models.StockShortfall) {
+	for _, s := range shortfalls {
+		productID := strconv.FormatInt(s.ProductID, 10)
+		stockRejectedDemand.WithLabelValues(productID).Add(float64(s.Requested - max(s.Available, 0)))
+		stockShortfalls.WithLabelValues(productID).Inc()
+	}
+}
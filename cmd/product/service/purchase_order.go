@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"productfc/infrastructure/log"
+	"productfc/models"
+)
+
+func (s *ProductService) CreatePurchaseOrder(ctx context.Context, req models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	order := &models.PurchaseOrder{
+		Supplier: req.Supplier,
+		Status:   models.PurchaseOrderStatusOpen,
+		Note:     req.Note,
+		Lines:    make([]models.PurchaseOrderLine, 0, len(req.Lines)),
+	}
+	for i, line := range req.Lines {
+		expectedAt := line.ExpectedAt
+		if expectedAt == nil {
+			expectedAt = req.ExpectedAt
+		}
+		if expectedAt == nil {
+			return nil, fmt.Errorf("%w: line %d has no expected_at", models.ErrInvalidPurchaseOrder, i+1)
+		}
+		order.Lines = append(order.Lines, models.PurchaseOrderLine{
+			ProductID:  line.ProductID,
+			Quantity:   line.Quantity,
+			ExpectedAt: *expectedAt,
+		})
+	}
+
+	if err := s.ProductRepo.CreatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	s.invalidateProductCaches(purchaseOrderItems(order), "Failed to invalidate product cache after purchase order create")
+	return order, nil
+}
+
+func (s *ProductService) GetPurchaseOrder(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	return s.ProductRepo.FindPurchaseOrder(ctx, id)
+}
+
+// ReceivePurchaseOrder — 전체/부분 입고. 재고 가산은 stock.changed(received)로, 백오더 할당은 stock.backorder_allocated로 발행.
+func (s *ProductService) ReceivePurchaseOrder(ctx context.Context, id int64, req models.ReceivePurchaseOrderRequest, receivedBy int64) (*models.PurchaseOrder, error) {
+	order, changes, err := s.ProductRepo.ReceivePurchaseOrder(ctx, id, req.Lines, receivedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProductCaches(purchaseOrderItems(order), "Failed to invalidate product cache after purchase order receipt")
+	s.publishStockChanges(ctx, models.StockChangeReceived, changes)
+	return order, nil
+}
+
+func (s *ProductService) CancelPurchaseOrder(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	order, err := s.ProductRepo.CancelPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateProductCaches(purchaseOrderItems(order), "Failed to invalidate product cache after purchase order cancel")
+	return order, nil
+}
+
+// attachIncoming — 조회 응답에 입고 예정 수량/예정일을 채움. 부가 정보라 실패하면 로그만 남기고 빈 값.
+func (s *ProductService) attachIncoming(ctx context.Context, products []*models.Product) {
+	ids := make([]int64, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+	incoming, err := s.ProductRepo.FindIncomingStocks(ctx, ids)
+	if err != nil {
+		log.Logger.Error().Err(err).Msg("Failed to load incoming stocks")
+		return
+	}
+	for _, product := range products {
+		product.Incoming = incoming[product.ID].Quantity
+		product.RestockETA = incoming[product.ID].RestockETA
+	}
+}
+
+func purchaseOrderItems(order *models.PurchaseOrder) []models.ProductItem {
+	items := make([]models.ProductItem, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		items = append(items, models.ProductItem{ProductID: line.ProductID})
+	}
+	return items
+}
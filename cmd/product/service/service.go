@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"productfc/cmd/product/repository"
+	"productfc/config"
+	"productfc/infrastructure/cache"
 	"productfc/infrastructure/log"
 	"productfc/infrastructure/redismonitor"
 	"productfc/models"
@@ -11,33 +14,32 @@ import (
 type ProductService struct {
 	ProductRepo  repository.ProductRepository
 	RedisMonitor *redismonitor.Monitor
+	Events       EventPublisher
+	StockConfig  config.StockConfig
 }
 
-func NewProductService(productRepo repository.ProductRepository, redisMonitor *redismonitor.Monitor) *ProductService {
-	return &ProductService{ProductRepo: productRepo, RedisMonitor: redisMonitor}
+func NewProductService(productRepo repository.ProductRepository, redisMonitor *redismonitor.Monitor, events EventPublisher, stockConfig config.StockConfig) *ProductService {
+	return &ProductService{ProductRepo: productRepo, RedisMonitor: redisMonitor, Events: events, StockConfig: stockConfig}
 }
 
 func (s *ProductService) GetProductById(ctx context.Context, id int64) (*models.Product, error) {
-	product, err := s.ProductRepo.GetProductByIdFromRedis(ctx, id)
+	product, err := s.ProductRepo.GetProductByIdFromCache(ctx, id)
 	if err != nil {
+		// 캐시 장애는 응답 실패로 이어지지 않게 DB로 폴백
 		if s.RedisMonitor != nil {
 			s.RedisMonitor.RecordError()
 		}
-		return nil, err
-	}
-	if product.ID > 0 {
+		if !errors.Is(err, cache.ErrCircuitOpen) {
+			log.Logger.Warn().Err(err).Int64("product_id", id).Msg("Failed to get product from cache - falling back to database")
+		}
+		product = &models.Product{}
+	} else if product.ID > 0 {
 		if s.RedisMonitor != nil {
 			s.RedisMonitor.RecordHit()
 		}
-		go func() {
-			if err := s.ProductRepo.IncrementProductView(context.Background(), id); err != nil {
-				log.Logger.Error().Err(err).Msg("Failed to increment product view")
-			}
-		}()
+		s.incrementProductView(id)
 		return product, nil
-	}
-
-	if s.RedisMonitor != nil {
+	} else if s.RedisMonitor != nil {
 		s.RedisMonitor.RecordMiss()
 	}
 
@@ -45,20 +47,31 @@ func (s *ProductService) GetProductById(ctx context.Context, id int64) (*models.
 	if err != nil {
 		return nil, err
 	}
+	s.attachIncoming(ctx, []*models.Product{product})
 
-	go func(product *models.Product) {
-		if err := s.ProductRepo.SetProductById(context.Background(), product); err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to cache product")
-		}
-	}(product)
+	if !s.ProductRepo.CacheDegraded() {
+		go func(product *models.Product) {
+			if err := s.ProductRepo.SetProductById(context.Background(), product); err != nil {
+				log.Logger.Error().Err(err).Msg("Failed to cache product")
+			}
+		}(product)
+	}
+
+	s.incrementProductView(id)
+
+	return product, nil
+}
 
+// incrementProductView — 랭킹 집계는 부가 기능이라 캐시가 degraded면 건너뜀.
+func (s *ProductService) incrementProductView(id int64) {
+	if s.ProductRepo.CacheDegraded() {
+		return
+	}
 	go func() {
 		if err := s.ProductRepo.IncrementProductView(context.Background(), id); err != nil {
 			log.Logger.Error().Err(err).Msg("Failed to increment product view")
 		}
 	}()
-
-	return product, nil
 }
 
 func (s *ProductService) GetProductCategoryById(ctx context.Context, id int) (*models.ProductCategory, error) {
@@ -74,6 +87,7 @@ func (s *ProductService) InsertNewProduct(ctx context.Context, product *models.P
 	if err != nil {
 		return 0, err
 	}
+	s.publishProductEvent(ctx, models.ProductEventCreated, productID, nil, product)
 	return productID, nil
 }
 
@@ -82,15 +96,32 @@ func (s *ProductService) InsertNewProductCategory(ctx context.Context, productCa
 	if err != nil {
 		return 0, err
 	}
+	s.publishCategoryEvent(ctx, models.CategoryEventCreated, productCategoryID, nil, productCategory)
 	return productCategoryID, nil
 }
 
 func (s *ProductService) EditProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
+	before := s.findProductForEvent(ctx, product.ID)
+
 	product, err := s.ProductRepo.UpdateProduct(ctx, product)
 	if err != nil {
 		return nil, err
 	}
 
+	after := s.findProductForEvent(ctx, product.ID)
+	if after == nil {
+		after = product
+	}
+	s.publishProductEvent(ctx, models.ProductEventUpdated, product.ID, before, after)
+	if before != nil && before.Stock != after.Stock {
+		s.publishStockChanges(ctx, models.StockChangeEdited, []models.StockChange{{
+			ProductID:  after.ID,
+			CategoryID: after.CategoryID,
+			OldStock:   before.Stock,
+			NewStock:   after.Stock,
+		}})
+	}
+
 	go func(id int64) {
 		if err := s.ProductRepo.InvalidateProductCache(context.Background(), id); err != nil {
 			log.Logger.Error().Err(err).Msg("Failed to invalidate product cache")
@@ -101,18 +132,38 @@ func (s *ProductService) EditProduct(ctx context.Context, product *models.Produc
 }
 
 func (s *ProductService) EditProductCategory(ctx context.Context, productCategory *models.ProductCategory) (*models.ProductCategory, error) {
+	before := s.findCategoryForEvent(ctx, productCategory.ID)
+
 	productCategory, err := s.ProductRepo.UpdateProductCategory(ctx, productCategory)
 	if err != nil {
 		return nil, err
 	}
+
+	after := s.findCategoryForEvent(ctx, productCategory.ID)
+	if after == nil {
+		after = productCategory
+	}
+	s.publishCategoryEvent(ctx, models.CategoryEventUpdated, productCategory.ID, before, after)
 	return productCategory, nil
 }
 
 func (s *ProductService) DeleteProductCategory(ctx context.Context, id int) error {
-	err := s.ProductRepo.DeleteProductCategory(ctx, id)
+	before := s.findCategoryForEvent(ctx, id)
+	// 카테고리 삭제 시 상품도 CASCADE로 지워지므로 상품 삭제 이벤트도 함께 발행
+	products, err := s.ProductRepo.FindProductsByCategoryId(ctx, id)
+	if err != nil {
+		log.Logger.Warn().Err(err).Int("category_id", id).Msg("Failed to load category products for delete events")
+	}
+
+	err = s.ProductRepo.DeleteProductCategory(ctx, id)
 	if err != nil {
 		return err
 	}
+
+	for i := range products {
+		s.publishProductEvent(ctx, models.ProductEventDeleted, products[i].ID, &products[i], nil)
+	}
+	s.publishCategoryEvent(ctx, models.CategoryEventDeleted, id, before, nil)
 	return nil
 }
 
@@ -121,10 +172,14 @@ func (s *ProductService) DeleteProduct(ctx context.Context, id int64) error {
 		log.Logger.Error().Err(err).Msg("Failed to invalidate product cache before delete")
 	}
 
+	before := s.findProductForEvent(ctx, id)
+
 	err := s.ProductRepo.DeleteProduct(ctx, id)
 	if err != nil {
 		return err
 	}
+
+	s.publishProductEvent(ctx, models.ProductEventDeleted, id, before, nil)
 	return nil
 }
 
@@ -133,11 +188,16 @@ func (s *ProductService) SearchProducts(ctx context.Context, params models.Searc
 	if err != nil {
 		return nil, 0, err
 	}
+	refs := make([]*models.Product, 0, len(products))
+	for i := range products {
+		refs = append(refs, &products[i])
+	}
+	s.attachIncoming(ctx, refs)
 	return products, totalCount, nil
 }
 
 func (s *ProductService) UpdateProductStockByProductID(ctx context.Context, productID int64, qty int) error {
-	err := s.ProductRepo.UpdateProductStockByProductID(ctx, productID, qty)
+	change, err := s.ProductRepo.UpdateProductStockByProductID(ctx, productID, qty)
 	if err != nil {
 		return err
 	}
@@ -148,21 +208,43 @@ func (s *ProductService) UpdateProductStockByProductID(ctx context.Context, prod
 		}
 	}()
 
+	s.publishStockChanges(ctx, models.StockChangeReserved, []models.StockChange{change})
 	return nil
 }
 
-func (s *ProductService) UpdateProductStocks(ctx context.Context, items []models.ProductItem) error {
-	err := s.ProductRepo.UpdateProductStocks(ctx, items)
+// UpdateProductStocks — 주문 재고 전량 차감 (stock.updated). 예약(라인별 차감 수량)은 부분 롤백에서 참조.
+func (s *ProductService) UpdateProductStocks(ctx context.Context, orderID int64, items []models.ProductItem) (*models.StockReservation, error) {
+	return s.reserveStocks(ctx, orderID, items, false)
+}
+
+// ReserveOrderStocks — order.created 재고 예약. stock.partial_fulfillment면 있는 만큼만 예약하고
+// 나머지는 reservation.Shortfalls. 모자란 상품은 미충족 수요 메트릭에 기록.
+func (s *ProductService) ReserveOrderStocks(ctx context.Context, orderID int64, items []models.ProductItem) (*models.StockReservation, error) {
+	reservation, err := s.reserveStocks(ctx, orderID, items, s.StockConfig.PartialFulfillment)
+	var insufficient *models.InsufficientStockError
+	switch {
+	case errors.As(err, &insufficient):
+		recordRejectedDemand(insufficient.Shortfalls)
+	case err == nil:
+		recordRejectedDemand(reservation.Shortfalls)
+	}
+	return reservation, err
+}
+
+func (s *ProductService) reserveStocks(ctx context.Context, orderID int64, items []models.ProductItem, partial bool) (*models.StockReservation, error) {
+	reservation, changes, err := s.ProductRepo.UpdateProductStocks(ctx, orderID, items, partial)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	s.invalidateProductCaches(items, "Failed to invalidate product cache after stock update")
-	return nil
+	s.publishStockChanges(ctx, models.StockChangeReserved, changes)
+	s.publishBackordered(ctx, reservation)
+	return reservation, nil
 }
 
 func (s *ProductService) AddProductStockByProductID(ctx context.Context, productID int64, qty int) error {
-	err := s.ProductRepo.AddProductStockByProductID(ctx, productID, qty)
+	change, err := s.ProductRepo.AddProductStockByProductID(ctx, productID, qty)
 	if err != nil {
 		return err
 	}
@@ -173,21 +255,45 @@ func (s *ProductService) AddProductStockByProductID(ctx context.Context, product
 		}
 	}()
 
+	s.publishStockChanges(ctx, models.StockChangeRestored, []models.StockChange{change})
 	return nil
 }
 
 func (s *ProductService) AddProductStocks(ctx context.Context, items []models.ProductItem) error {
-	err := s.ProductRepo.AddProductStocks(ctx, items)
+	changes, err := s.ProductRepo.AddProductStocks(ctx, items)
 	if err != nil {
 		return err
 	}
 
 	s.invalidateProductCaches(items, "Failed to invalidate product cache after stock add")
+	s.publishStockChanges(ctx, models.StockChangeRestored, changes)
+	return nil
+}
+
+// FindOrderReservation — 커밋된 예약으로 stock.reserved를 다시 만들 때 사용 (주문 중복 수신).
+func (s *ProductService) FindOrderReservation(ctx context.Context, orderID int64) (*models.StockReservation, error) {
+	return s.ProductRepo.FindOrderReservation(ctx, orderID)
+}
+
+// RestoreReservedStocks — 주문 전체/일부 롤백. 예약 수량을 넘는 복원은 models.ErrRestoreExceedsReserved.
+func (s *ProductService) RestoreReservedStocks(ctx context.Context, event models.ProductStockRollbackEvent) error {
+	changes, err := s.ProductRepo.RestoreReservedStocks(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	s.invalidateProductCaches(event.Products, "Failed to invalidate product cache after stock restore")
+	s.publishStockChanges(ctx, models.StockChangeRestored, changes)
 	return nil
 }
 
 func (s *ProductService) GetTopProducts(ctx context.Context, limit int64) ([]models.ProductRankingItem, error) {
-	return s.ProductRepo.GetTopProducts(ctx, limit)
+	ranking, err := s.ProductRepo.GetTopProducts(ctx, limit)
+	if errors.Is(err, cache.ErrCircuitOpen) {
+		// 랭킹은 Redis에만 있으므로 degraded 동안은 빈 목록
+		return []models.ProductRankingItem{}, nil
+	}
+	return ranking, err
 }
 
 func (s *ProductService) invalidateProductCaches(items []models.ProductItem, msg string) {
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"productfc/infrastructure/log"
+	kafkapkg "productfc/kafka"
+	"productfc/models"
+	"time"
+)
+
+// publishStockChanges — stock.changed 발행 후 임계치를 넘나든 상품은 stock.low/out/back_in_stock,
+// 입고분이 백오더에 할당됐으면 stock.backorder_allocated 발행. 재고 변경은 이미 커밋됐으므로 실패는 로그만 남김.
+func (s *ProductService) publishStockChanges(ctx context.Context, reason string, changes []models.StockChange) {
+	if s.Events == nil {
+		return
+	}
+	for _, change := range changes {
+		if change.OldStock == change.NewStock {
+			continue
+		}
+		event := models.StockChangedEvent{
+			SchemaVersion: kafkapkg.SchemaVersionStockChanged,
+			ProductID:     change.ProductID,
+			CategoryID:    change.CategoryID,
+			OldStock:      change.OldStock,
+			NewStock:      change.NewStock,
+			Delta:         change.NewStock - change.OldStock,
+			Reason:        reason,
+			EventTime:     time.Now(),
+		}
+		if err := s.Events.PublishStockChanged(ctx, event); err != nil {
+			log.Logger.Error().Err(err).Int64("product_id", change.ProductID).Msg("Failed to publish stock.changed")
+		}
+		s.publishStockLevel(ctx, change)
+	}
+	s.publishBackorderAllocations(ctx, changes)
+}
+
+// publishStockLevel — 수준이 바뀐 경우에만 발행. 알림 상태 테이블로 같은 수준 이벤트 반복을 막고,
+// 발행에 성공한 전이만 기록 (실패하면 다음 재고 변경에서 다시 발행).
+func (s *ProductService) publishStockLevel(ctx context.Context, change models.StockChange) {
+	threshold, err := s.lowStockThreshold(ctx, change)
+	if err != nil {
+		log.Logger.Error().Err(err).Int64("product_id", change.ProductID).Msg("Failed to load low stock threshold")
+		return
+	}
+
+	level := models.StockLevel(change.NewStock, threshold)
+	initial := models.StockLevel(change.OldStock, threshold)
+	_, err = s.ProductRepo.TransitionStockAlertLevel(ctx, change.ProductID, initial, level, func(previous string) error {
+		return s.publishStockLevelEvents(ctx, change, threshold, previous, level)
+	})
+	if err != nil {
+		log.Logger.Error().Err(err).Int64("product_id", change.ProductID).Msg("Failed to publish stock level transition")
+	}
+}
+
+// publishStockLevelEvents — previous → level 전이에 해당하는 이벤트 발행. 하나라도 실패하면 에러 (전이 미기록).
+func (s *ProductService) publishStockLevelEvents(ctx context.Context, change models.StockChange, threshold int, previous, level string) error {
+	var eventTypes []string
+	if previous == models.StockLevelOut {
+		eventTypes = append(eventTypes, models.StockEventBackInStock)
+	}
+	switch level {
+	case models.StockLevelOut:
+		eventTypes = append(eventTypes, models.StockEventOut)
+	case models.StockLevelLow:
+		eventTypes = append(eventTypes, models.StockEventLow)
+	}
+
+	for _, eventType := range eventTypes {
+		event := models.StockLevelEvent{
+			SchemaVersion:     kafkapkg.SchemaVersionStockEvent,
+			EventType:         eventType,
+			ProductID:         change.ProductID,
+			CategoryID:        change.CategoryID,
+			Stock:             change.NewStock,
+			LowStockThreshold: threshold,
+			PreviousLevel:     previous,
+			EventTime:         time.Now(),
+		}
+		if err := s.Events.PublishStockLevel(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish %s: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+func (s *ProductService) lowStockThreshold(ctx context.Context, change models.StockChange) (int, error) {
+	threshold, found, err := s.ProductRepo.FindLowStockThreshold(ctx, change.ProductID, change.CategoryID)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return s.StockConfig.LowStockThreshold, nil
+	}
+	return threshold, nil
+}
+
+func (s *ProductService) SetProductStockThreshold(ctx context.Context, productID int64, lowStockThreshold int) (*models.StockThreshold, error) {
+	exists, err := s.ProductRepo.ProductExists(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrProductNotFound
+	}
+	return s.ProductRepo.UpsertProductStockThreshold(ctx, productID, lowStockThreshold)
+}
+
+func (s *ProductService) SetCategoryStockThreshold(ctx context.Context, categoryID int, lowStockThreshold int) (*models.StockThreshold, error) {
+	category, err := s.ProductRepo.FindProductCategoryById(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category.ID == 0 {
+		return nil, models.ErrCategoryNotFound
+	}
+	return s.ProductRepo.UpsertCategoryStockThreshold(ctx, categoryID, lowStockThreshold)
+}
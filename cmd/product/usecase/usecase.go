@@ -92,3 +92,43 @@ func (u *ProductUsecase) SearchProducts(ctx context.Context, params models.Searc
 func (u *ProductUsecase) GetTopProducts(ctx context.Context, limit int64) ([]models.ProductRankingItem, error) {
 	return u.ProductService.GetTopProducts(ctx, limit)
 }
+
+func (u *ProductUsecase) SetProductStockThreshold(ctx context.Context, productID int64, lowStockThreshold int) (*models.StockThreshold, error) {
+	return u.ProductService.SetProductStockThreshold(ctx, productID, lowStockThreshold)
+}
+
+func (u *ProductUsecase) SetCategoryStockThreshold(ctx context.Context, categoryID int, lowStockThreshold int) (*models.StockThreshold, error) {
+	return u.ProductService.SetCategoryStockThreshold(ctx, categoryID, lowStockThreshold)
+}
+
+func (u *ProductUsecase) GetBackorderSetting(ctx context.Context, productID int64) (*models.BackorderSetting, error) {
+	return u.ProductService.GetBackorderSetting(ctx, productID)
+}
+
+func (u *ProductUsecase) SetBackorderSetting(ctx context.Context, productID int64, req models.BackorderSettingRequest) (*models.BackorderSetting, error) {
+	return u.ProductService.SetBackorderSetting(ctx, productID, req)
+}
+
+func (u *ProductUsecase) AdjustProductStock(ctx context.Context, productID int64, req models.StockAdjustmentRequest, adjustedBy int64) (*models.StockAdjustment, error) {
+	return u.ProductService.AdjustProductStock(ctx, productID, req, adjustedBy)
+}
+
+func (u *ProductUsecase) ApplyStocktake(ctx context.Context, req models.StocktakeRequest, adjustedBy int64) (*models.StocktakeResult, error) {
+	return u.ProductService.ApplyStocktake(ctx, req, adjustedBy)
+}
+
+func (u *ProductUsecase) CreatePurchaseOrder(ctx context.Context, req models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	return u.ProductService.CreatePurchaseOrder(ctx, req)
+}
+
+func (u *ProductUsecase) GetPurchaseOrder(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	return u.ProductService.GetPurchaseOrder(ctx, id)
+}
+
+func (u *ProductUsecase) ReceivePurchaseOrder(ctx context.Context, id int64, req models.ReceivePurchaseOrderRequest, receivedBy int64) (*models.PurchaseOrder, error) {
+	return u.ProductService.ReceivePurchaseOrder(ctx, id, req, receivedBy)
+}
+
+func (u *ProductUsecase) CancelPurchaseOrder(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	return u.ProductService.CancelPurchaseOrder(ctx, id)
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"productfc/kafka/schema"
+	"sort"
+	"text/tabwriter"
+)
+
+const usage = `usage: productfc schema <command>
+
+commands:
+  list     이벤트 스키마와 현재(발행) 버전
+  verify   버전별 골든 샘플 검증 (스키마 검증 + 업캐스트 + 재인코딩 왕복)
+`
+
+// Run — "productfc schema ..." 서브커맨드 실행. 종료 코드 반환 (verify 실패 시 1 → CI에서 사용).
+func Run(args []string, out io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(out, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		list(schema.Default, out)
+		return 0
+	case "verify":
+		return verify(schema.Default, out)
+	default:
+		fmt.Fprintf(out, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+func list(registry *schema.Registry, out io.Writer) {
+	subjects := registry.Subjects()
+	names := make([]string, 0, len(subjects))
+	for name := range subjects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SCHEMA\tCURRENT")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\tv%d\n", name, subjects[name])
+	}
+	w.Flush()
+}
+
+func verify(registry *schema.Registry, out io.Writer) int {
+	results, err := registry.Verify()
+	if err != nil {
+		fmt.Fprintf(out, "error: %v\n", err)
+		return 1
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Fprintf(out, "FAIL  %s v%d: %v\n", r.Subject, r.Version, r.Err)
+			continue
+		}
+		fmt.Fprintf(out, "ok    %s v%d\n", r.Subject, r.Version)
+	}
+	fmt.Fprintf(out, "\n%d samples, %d failed\n", len(results), failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
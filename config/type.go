@@ -1,10 +1,129 @@
 package config
 
+import "time"
+
 type Config struct {
 	App      AppConfig      `yaml:"app" validate:"required"`
 	Database DatabaseConfig `yaml:"database" validate:"required"`
 	Redis    RedisConfig    `yaml:"redis" validate:"required"`
+	Cache    CacheConfig    `yaml:"cache"`
 	Tracing  TracingConfig  `yaml:"tracing"`
+	Kafka    KafkaConfig    `yaml:"kafka"`
+	Stock    StockConfig    `yaml:"stock"`
+}
+
+// StockConfig — low_stock_threshold: 상품/카테고리별 설정이 없을 때 쓰는 저재고 임계치.
+type StockConfig struct {
+	LowStockThreshold int `yaml:"low_stock_threshold" mapstructure:"low_stock_threshold"`
+	// PartialFulfillment — order.created에서 재고가 모자라면 거절 대신 있는 만큼만 예약
+	PartialFulfillment bool `yaml:"partial_fulfillment" mapstructure:"partial_fulfillment"`
+}
+
+// KafkaConfig — commit_interval: 0이면 처리 완료 메시지마다 동기 커밋, >0이면 해당 주기로 모아서 커밋.
+// retry_tiers: 처리 실패 메시지를 지연 재처리할 단계 (<topic>.retry.<delay>), 마지막 단계 후 DLQ.
+// min_bytes/max_bytes/concurrency/workers/max_in_flight는 컨슈머 공통 기본값, consumers.<name>에서 개별 지정 가능.
+// workers > 1이면 reader마다 메시지 키별 순서를 지키며 동시 처리, max_in_flight는 처리 중 메시지 상한.
+type KafkaConfig struct {
+	Brokers        []string                       `yaml:"brokers" mapstructure:"brokers"`
+	ClientID       string                         `yaml:"client_id" mapstructure:"client_id"`
+	SASL           KafkaSASLConfig                `yaml:"sasl" mapstructure:"sasl"`
+	TLS            KafkaTLSConfig                 `yaml:"tls" mapstructure:"tls"`
+	Topics         KafkaTopicsConfig              `yaml:"topics" mapstructure:"topics"`
+	MinBytes       int                            `yaml:"min_bytes" mapstructure:"min_bytes"`
+	MaxBytes       int                            `yaml:"max_bytes" mapstructure:"max_bytes"`
+	Concurrency    int                            `yaml:"concurrency" mapstructure:"concurrency"`
+	Workers        int                            `yaml:"workers" mapstructure:"workers"`
+	MaxInFlight    int                            `yaml:"max_in_flight" mapstructure:"max_in_flight"`
+	CommitInterval time.Duration                  `yaml:"commit_interval" mapstructure:"commit_interval"`
+	RetryTiers     []time.Duration                `yaml:"retry_tiers" mapstructure:"retry_tiers"`
+	Consumers      map[string]KafkaConsumerConfig `yaml:"consumers" mapstructure:"consumers"`
+	CloudEvents    KafkaCloudEventsConfig         `yaml:"cloudevents" mapstructure:"cloudevents"`
+	Codec          KafkaCodecConfig               `yaml:"codec" mapstructure:"codec"`
+	Idempotency    KafkaIdempotencyConfig         `yaml:"idempotency" mapstructure:"idempotency"`
+}
+
+// KafkaSASLConfig — mechanism: 비어 있으면 SASL 미사용 | plain | scram-sha-256 | scram-sha-512.
+type KafkaSASLConfig struct {
+	Mechanism string `yaml:"mechanism" mapstructure:"mechanism"`
+	Username  string `yaml:"username" mapstructure:"username"`
+	Password  string `yaml:"password" mapstructure:"password"`
+}
+
+// KafkaTLSConfig — ca_file 없으면 시스템 CA, cert_file/key_file은 mTLS일 때만.
+type KafkaTLSConfig struct {
+	Enabled            bool   `yaml:"enabled" mapstructure:"enabled"`
+	CAFile             string `yaml:"ca_file" mapstructure:"ca_file"`
+	CertFile           string `yaml:"cert_file" mapstructure:"cert_file"`
+	KeyFile            string `yaml:"key_file" mapstructure:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify" mapstructure:"insecure_skip_verify"`
+}
+
+// KafkaTopicsConfig — 토픽 이름 재정의 (비어 있으면 kafka/constant.go 기본값).
+type KafkaTopicsConfig struct {
+	OrderCreated     string `yaml:"order_created" mapstructure:"order_created"`
+	StockReserved    string `yaml:"stock_reserved" mapstructure:"stock_reserved"`
+	StockRejected    string `yaml:"stock_rejected" mapstructure:"stock_rejected"`
+	StockUpdated     string `yaml:"stock_updated" mapstructure:"stock_updated"`
+	StockRollback    string `yaml:"stock_rollback" mapstructure:"stock_rollback"`
+	DLQOrderCreated  string `yaml:"dlq_order_created" mapstructure:"dlq_order_created"`
+	DLQStockUpdated  string `yaml:"dlq_stock_updated" mapstructure:"dlq_stock_updated"`
+	DLQStockRollback string `yaml:"dlq_stock_rollback" mapstructure:"dlq_stock_rollback"`
+	ProductCreated   string `yaml:"product_created" mapstructure:"product_created"`
+	ProductUpdated   string `yaml:"product_updated" mapstructure:"product_updated"`
+	ProductDeleted   string `yaml:"product_deleted" mapstructure:"product_deleted"`
+	CategoryCreated  string `yaml:"category_created" mapstructure:"category_created"`
+	CategoryUpdated  string `yaml:"category_updated" mapstructure:"category_updated"`
+	CategoryDeleted  string `yaml:"category_deleted" mapstructure:"category_deleted"`
+	ProductSnapshot  string `yaml:"product_snapshot" mapstructure:"product_snapshot"`
+	StockChanged     string `yaml:"stock_changed" mapstructure:"stock_changed"`
+	StockLow         string `yaml:"stock_low" mapstructure:"stock_low"`
+	StockOut         string `yaml:"stock_out" mapstructure:"stock_out"`
+	StockBackInStock string `yaml:"stock_back_in_stock" mapstructure:"stock_back_in_stock"`
+
+	StockBackordered        string `yaml:"stock_backordered" mapstructure:"stock_backordered"`
+	StockBackorderAllocated string `yaml:"stock_backorder_allocated" mapstructure:"stock_backorder_allocated"`
+}
+
+// KafkaCloudEventsConfig — 발행 메시지 인코딩. mode: binary(기본, ce_* 헤더) | structured | none(레거시 본문만).
+// topics는 KafkaTopicsConfig와 같은 이름(stock_reserved 등)으로 토픽별 mode 재정의.
+type KafkaCloudEventsConfig struct {
+	Source     string            `yaml:"source" mapstructure:"source"`
+	TypePrefix string            `yaml:"type_prefix" mapstructure:"type_prefix"`
+	Mode       string            `yaml:"mode" mapstructure:"mode"`
+	Topics     map[string]string `yaml:"topics" mapstructure:"topics"`
+}
+
+// KafkaCodecConfig — 발행 본문 형식: json(기본) | protobuf | avro. topics는 KafkaTopicsConfig 이름으로 토픽별 재정의.
+// 수신은 content-type 헤더로 형식을 자동 선택.
+type KafkaCodecConfig struct {
+	Default string            `yaml:"default" mapstructure:"default"`
+	Topics  map[string]string `yaml:"topics" mapstructure:"topics"`
+}
+
+// KafkaIdempotencyConfig — 컨슈머 멱등성 저장소: postgres(기본, 재고 변경과 같은 트랜잭션에 기록) | redis.
+// lease는 한 인스턴스가 이벤트를 점유하는 시간, done_ttl은 완료 기록 보관 기간.
+type KafkaIdempotencyConfig struct {
+	Store   string        `yaml:"store" mapstructure:"store"`
+	Lease   time.Duration `yaml:"lease" mapstructure:"lease"`
+	DoneTTL time.Duration `yaml:"done_ttl" mapstructure:"done_ttl"`
+}
+
+// KafkaConsumerConfig — 컨슈머별 설정 (order_created, stock_updated, stock_rollback, dlq_indexer).
+// enabled 생략 시 활성, 0/빈 값은 KafkaConfig 공통값 사용.
+type KafkaConsumerConfig struct {
+	Enabled     *bool  `yaml:"enabled" mapstructure:"enabled"`
+	GroupID     string `yaml:"group_id" mapstructure:"group_id"`
+	Concurrency int    `yaml:"concurrency" mapstructure:"concurrency"`
+	Workers     int    `yaml:"workers" mapstructure:"workers"`
+	MaxInFlight int    `yaml:"max_in_flight" mapstructure:"max_in_flight"`
+	MinBytes    int    `yaml:"min_bytes" mapstructure:"min_bytes"`
+	MaxBytes    int    `yaml:"max_bytes" mapstructure:"max_bytes"`
+	// RequireCloudEvents — true면 CloudEvents가 아닌 레거시 본문은 DLQ (기본: 둘 다 허용).
+	RequireCloudEvents bool `yaml:"require_cloudevents" mapstructure:"require_cloudevents"`
+}
+
+func (c KafkaConsumerConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
 }
 
 type TracingConfig struct {
@@ -14,7 +133,8 @@ type TracingConfig struct {
 }
 
 type AppConfig struct {
-	Port string `yaml:"port" validate:"required"`
+	Port            string        `yaml:"port" validate:"required"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" mapstructure:"shutdown_timeout"`
 }
 
 type DatabaseConfig struct {
@@ -25,6 +145,14 @@ type DatabaseConfig struct {
 	Name     string `yaml:"name" validate:"required"`
 }
 
+// CacheConfig — driver: redis(기본) | memory (Redis 없이 기동하는 degraded 모드).
+// breaker_*: Redis 연속 실패 시 DB 폴백으로 전환하는 circuit breaker 설정.
+type CacheConfig struct {
+	Driver                  string        `yaml:"driver" mapstructure:"driver"`
+	BreakerFailureThreshold int           `yaml:"breaker_failure_threshold" mapstructure:"breaker_failure_threshold"`
+	BreakerOpenTimeout      time.Duration `yaml:"breaker_open_timeout" mapstructure:"breaker_open_timeout"`
+}
+
 type RedisConfig struct {
 	Host     string `yaml:"host" validate:"required"`
 	Port     string `yaml:"port" validate:"required"`
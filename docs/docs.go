@@ -15,40 +15,161 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/product-categories": {
-            "post": {
+        "/api/v1/admin/dlq": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "새로운 상품 카테고리를 생성합니다.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "DLQ 토픽/원본 토픽/주문 ID/에러 문구/상태로 DLQ 메시지를 검색합니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "DLQ"
                 ],
-                "summary": "카테고리 생성",
+                "summary": "DLQ 메시지 목록 조회",
                 "parameters": [
                     {
-                        "description": "카테고리 생성 요청",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "DLQ 토픽 (예: stock.updated.dlq)",
+                        "name": "dlq_topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "원본 토픽",
+                        "name": "original_topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "주문 ID",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "에러 메시지 포함 문구",
+                        "name": "error",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "실패 분류 (processing/decode/schema)",
+                        "name": "error_class",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "상태 (pending/replayed/resolved)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "페이지 번호",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "페이지 크기",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.DLQSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
+                }
+            }
+        },
+        "/api/v1/admin/dlq/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "DLQ 토픽/상태별 메시지 건수를 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DLQ"
                 ],
+                "summary": "DLQ 통계",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DLQCount"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/dlq/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "DLQ 메시지 ID로 원본 본문과 에러를 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DLQ"
+                ],
+                "summary": "DLQ 메시지 단건 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "DLQ 메시지 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DLQMessage"
                         }
                     },
                     "400": {
@@ -58,6 +179,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -68,14 +196,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/product-categories/{id}": {
-            "put": {
+        "/api/v1/admin/dlq/{id}/replay": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "카테고리 ID에 해당하는 카테고리 정보를 수정합니다.",
+                "description": "DLQ 메시지를 원본 토픽으로 다시 발행합니다. body를 주면 수정한 본문을 JSON(content-type application/json)으로, 새 이벤트 ID를 붙여 발행합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -83,24 +211,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "DLQ"
                 ],
-                "summary": "카테고리 수정",
+                "summary": "DLQ 메시지 재처리",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "카테고리 ID",
+                        "description": "DLQ 메시지 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "카테고리 수정 요청",
+                        "description": "재처리 요청 (수정 본문)",
                         "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.DLQReplayRequest"
                         }
                     }
                 ],
@@ -108,7 +235,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.DLQMessage"
                         }
                     },
                     "400": {
@@ -118,6 +245,20 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -126,36 +267,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/admin/dlq/{id}/resolve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "카테고리 ID에 해당하는 카테고리를 삭제합니다.",
+                "description": "재처리 없이 DLQ 메시지를 해결됨(resolved)으로 표시합니다.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "DLQ"
                 ],
-                "summary": "카테고리 삭제",
+                "summary": "DLQ 메시지 해결 처리",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "카테고리 ID",
+                        "description": "DLQ 메시지 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "해결 메모",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.DLQResolveRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.DLQMessage"
                         }
                     },
                     "400": {
@@ -165,6 +318,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -175,14 +335,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/products": {
+        "/api/v1/product-categories": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "새로운 상품을 생성합니다.",
+                "description": "새로운 상품 카테고리를 생성합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,15 +352,15 @@ const docTemplate = `{
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "상품 생성",
+                "summary": "카테고리 생성",
                 "parameters": [
                     {
-                        "description": "상품 생성 요청",
+                        "description": "카테고리 생성 요청",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.ProductCategory"
                         }
                     }
                 ],
@@ -208,7 +368,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.ProductCategory"
                         }
                     },
                     "400": {
@@ -228,14 +388,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/products/{id}": {
+        "/api/v1/product-categories/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "상품 ID에 해당하는 상품 정보를 수정합니다.",
+                "description": "카테고리 ID에 해당하는 카테고리 정보를 수정합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -245,22 +405,22 @@ const docTemplate = `{
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "상품 수정",
+                "summary": "카테고리 수정",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "상품 ID",
+                        "description": "카테고리 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "상품 수정 요청",
+                        "description": "카테고리 수정 요청",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.ProductCategory"
                         }
                     }
                 ],
@@ -268,7 +428,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.ProductCategory"
                         }
                     },
                     "400": {
@@ -293,18 +453,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "상품 ID에 해당하는 상품을 삭제합니다.",
+                "description": "카테고리 ID에 해당하는 카테고리를 삭제합니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "상품 삭제",
+                "summary": "카테고리 삭제",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "상품 ID",
+                        "description": "카테고리 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -335,16 +495,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/product-categories/{id}": {
-            "get": {
-                "description": "카테고리 ID로 카테고리를 조회합니다.",
+        "/api/v1/product-categories/{id}/stock-threshold": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "카테고리에 속한 상품의 기본 저재고 임계치를 설정합니다.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "카테고리 단건 조회",
+                "summary": "카테고리 저재고 임계치 설정",
                 "parameters": [
                     {
                         "type": "integer",
@@ -352,13 +520,22 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "임계치 설정 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockThresholdRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.StockThreshold"
                         }
                     },
                     "400": {
@@ -368,6 +545,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -378,34 +562,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/products/ranking": {
-            "get": {
-                "description": "조회수/점수 기준 상위 상품 랭킹을 조회합니다.",
+        "/api/v1/products": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "새로운 상품을 생성합니다.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "상품 랭킹 조회",
+                "summary": "상품 생성",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "랭킹 개수",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
+                        "description": "상품 생성 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "additionalProperties": true
-                            }
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -418,73 +615,154 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/products/search": {
-            "get": {
-                "description": "이름/카테고리/가격/정렬 조건으로 상품을 검색합니다.",
+        "/api/v1/products/stocktake": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "실사한 실물 수량(절대값)으로 여러 상품의 재고를 한 번에 반영하고 상품별 차이(variance = counted - expected)를 반환합니다.\nexpected는 재고 + 미할당 백오더이며, 미할당 백오더 수량은 반영 후에도 음수 재고로 유지됩니다.\n없는 상품이 하나라도 있으면 아무것도 반영하지 않습니다.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "상품 검색",
+                "summary": "재고 실사 일괄 반영",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "상품명",
-                        "name": "name",
-                        "in": "query"
+                        "description": "실사 업로드 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StocktakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StocktakeResult"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "카테고리명",
-                        "name": "category",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    {
-                        "type": "number",
-                        "description": "최소 가격",
-                        "name": "min_price",
-                        "in": "query"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}": {
+            "put": {
+                "security": [
                     {
-                        "type": "number",
-                        "description": "최대 가격",
-                        "name": "max_price",
-                        "in": "query"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "상품 ID에 해당하는 상품 정보를 수정합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 수정",
+                "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "페이지 번호",
-                        "name": "page",
-                        "in": "query"
+                        "description": "상품 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "페이지 크기",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "상품 수정 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "정렬 컬럼",
-                        "name": "order_by",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "정렬 방향 (asc/desc)",
-                        "name": "sort",
-                        "in": "query"
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "상품 ID에 해당하는 상품을 삭제합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 삭제",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "상품 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SearchProductResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -504,16 +782,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/products/{id}": {
+        "/api/v1/products/{id}/backorder": {
             "get": {
-                "description": "상품 ID로 상품 정보를 조회합니다.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "상품의 백오더/예약 주문 설정을 조회합니다. 설정이 없으면 허용 안 함(allowed=false)으로 반환합니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "PRODUCT"
                 ],
-                "summary": "상품 단건 조회",
+                "summary": "상품 백오더 설정 조회",
                 "parameters": [
                     {
                         "type": "integer",
@@ -527,7 +810,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.BackorderSetting"
                         }
                     },
                     "400": {
@@ -537,6 +820,78 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "재고가 없어도 주문을 받을지(백오더/예약 주문), 최대 백오더 수량(0이면 무제한), 입고 예정일을 설정합니다.\n백오더로 받은 수량은 stock.backordered 이벤트로 발행되고, 입고되면 먼저 받은 주문부터 할당됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 백오더 설정",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "상품 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "백오더 설정 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BackorderSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BackorderSetting"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -546,9 +901,840 @@ const docTemplate = `{
                     }
                 }
             }
-        }
+        },
+        "/api/v1/products/{id}/stock-adjustments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "상품 재고를 상대 수량만큼 조정하고 조정 이력을 남깁니다. 사유: damage(파손, 감소만), recount(재집계), receipt(입고, 증가만).\n늘어난 수량은 대기 중인 백오더에 먼저 할당되며, 실물 재고(재고 + 미할당 백오더)가 음수가 되는 조정은 409를 반환합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 재고 조정",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "상품 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "재고 조정 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/stock-threshold": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "상품별 저재고 임계치를 설정합니다. 재고가 임계치 이하로 내려가면 stock.low 이벤트가 발행됩니다. (카테고리 설정보다 우선)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 저재고 임계치 설정",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "상품 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "임계치 설정 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockThresholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockThreshold"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/purchase-orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "상품별 발주 수량과 입고 예정일로 발주를 생성합니다. 라인에 expected_at이 없으면 발주의 expected_at을 사용합니다.\n입고되지 않은 수량은 상품 조회 응답의 incoming / restock_eta로 노출됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE_ORDER"
+                ],
+                "summary": "발주 생성",
+                "parameters": [
+                    {
+                        "description": "발주 생성 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/purchase-orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "발주와 라인별 입고 현황을 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE_ORDER"
+                ],
+                "summary": "발주 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "발주 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/purchase-orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "남은 수량을 더 이상 입고하지 않도록 발주를 취소합니다. 이미 입고된 수량은 그대로 유지됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE_ORDER"
+                ],
+                "summary": "발주 취소",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "발주 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/purchase-orders/{id}/receipts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "발주 라인별 입고 수량을 재고에 가산합니다. lines를 비우면 남은 수량 전부를 입고합니다.\n입고된 수량은 대기 중인 백오더에 먼저 할당되며, 남은 수량을 넘는 입고나 종료된 발주는 거부됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE_ORDER"
+                ],
+                "summary": "발주 입고",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "발주 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "입고 요청",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReceivePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/product-categories/{id}": {
+            "get": {
+                "description": "카테고리 ID로 카테고리를 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "카테고리 단건 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "카테고리 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/ranking": {
+            "get": {
+                "description": "조회수/점수 기준 상위 상품 랭킹을 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 랭킹 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "랭킹 개수",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "additionalProperties": true
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/search": {
+            "get": {
+                "description": "이름/카테고리/가격/정렬 조건으로 상품을 검색합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 검색",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "상품명",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "카테고리명",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "최소 가격",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "최대 가격",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "페이지 번호",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "페이지 크기",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "정렬 컬럼",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "정렬 방향 (asc/desc)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/products/{id}": {
+            "get": {
+                "description": "상품 ID로 상품 정보를 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "상품 단건 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "상품 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
+        "models.BackorderSetting": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "boolean"
+                },
+                "expected_restock_at": {
+                    "type": "string"
+                },
+                "max_quantity": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BackorderSettingRequest": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "boolean"
+                },
+                "expected_restock_at": {
+                    "type": "string"
+                },
+                "max_quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "backorder",
+                        "preorder"
+                    ]
+                }
+            }
+        },
+        "models.CreatePurchaseOrderLine": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "expected_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.CreatePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "lines"
+            ],
+            "properties": {
+                "expected_at": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.CreatePurchaseOrderLine"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "supplier": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.DLQCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "dlq_topic": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DLQMessage": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "body_encoding": {
+                    "description": "BodyEncoding — json 또는 base64 (JSON이 아닌 원문, 예: 디코드 실패)",
+                    "type": "string"
+                },
+                "consumer_group": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dlq_topic": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_class": {
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "first_failed_at": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message_key": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "original_offset": {
+                    "type": "integer"
+                },
+                "original_partition": {
+                    "description": "원본 메시지 출처 (재시도 tier를 거쳤어도 최초 토픽 기준)",
+                    "type": "integer"
+                },
+                "original_topic": {
+                    "type": "string"
+                },
+                "partition": {
+                    "type": "integer"
+                },
+                "replay_count": {
+                    "type": "integer"
+                },
+                "replayed_at": {
+                    "type": "string"
+                },
+                "replayed_body": {
+                    "type": "string"
+                },
+                "resolve_note": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DLQReplayRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Body — 수정 후 재처리할 이벤트 JSON 본문 (생략 시 원본 그대로). 주면 content-type은 JSON, 이벤트 ID는 새로 발급",
+                    "type": "object"
+                }
+            }
+        },
+        "models.DLQResolveRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DLQSearchResponse": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DLQMessage"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.Product": {
             "type": "object",
             "properties": {
@@ -564,12 +1750,19 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "incoming": {
+                    "description": "Incoming/RestockETA — 열린 발주의 미입고 수량과 아직 지나지 않은 가장 이른 입고 예정일 (조회 응답용, 이벤트에는 없음)",
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
                 "price": {
                     "type": "number"
                 },
+                "restock_eta": {
+                    "type": "string"
+                },
                 "stock": {
                     "type": "integer"
                 }
@@ -586,6 +1779,86 @@ const docTemplate = `{
                 }
             }
         },
+        "models.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurchaseOrderLine": {
+            "type": "object",
+            "properties": {
+                "expected_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_order_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "received_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ReceivePurchaseOrderLine": {
+            "type": "object",
+            "required": [
+                "line_id",
+                "quantity"
+            ],
+            "properties": {
+                "line_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.ReceivePurchaseOrderRequest": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReceivePurchaseOrderLine"
+                    }
+                }
+            }
+        },
         "models.SearchProductResponse": {
             "type": "object",
             "properties": {
@@ -611,6 +1884,167 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.StockAdjustment": {
+            "type": "object",
+            "properties": {
+                "adjusted_by": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_stock": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "old_stock": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stocktake_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StockAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "delta",
+                "reason"
+            ],
+            "properties": {
+                "delta": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "damage",
+                        "recount",
+                        "receipt"
+                    ]
+                }
+            }
+        },
+        "models.StockThreshold": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StockThresholdRequest": {
+            "type": "object",
+            "properties": {
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.StocktakeItem": {
+            "type": "object",
+            "required": [
+                "counted",
+                "product_id"
+            ],
+            "properties": {
+                "counted": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "product_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.StocktakeRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.StocktakeItem"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.StocktakeResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StocktakeVariance"
+                    }
+                },
+                "mismatched": {
+                    "type": "integer"
+                },
+                "stocktake_id": {
+                    "type": "string"
+                },
+                "total_variance": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StocktakeVariance": {
+            "type": "object",
+            "properties": {
+                "counted": {
+                    "type": "integer"
+                },
+                "expected": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "variance": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
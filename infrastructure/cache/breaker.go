@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 10 * time.Second
+)
+
+// ErrCircuitOpen — breaker가 열려 있어 캐시 호출을 건너뜀 (호출자는 DB 폴백).
+var ErrCircuitOpen = errors.New("cache: circuit breaker open")
+
+// CircuitBreaker — Cache 호출 실패가 연속 threshold회 이상이면 openTimeout 동안 즉시 실패시키고,
+// 이후 한 건의 probe 호출(half-open)이 성공하면 자동으로 복구.
+type CircuitBreaker struct {
+	next        Cache
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(next Cache, threshold int, openTimeout time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+	return &CircuitBreaker{
+		next:        next,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		state:       BreakerClosed,
+	}
+}
+
+// State — 현재 breaker 상태 (open 유지 시간이 지났으면 half_open으로 보고).
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.openTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Degraded — closed가 아니면 true (랭킹 쓰기 등 부가 작업 생략 판단용).
+func (b *CircuitBreaker) Degraded() bool {
+	return b.State() != BreakerClosed
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerClosed:
+		return nil
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	default:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	}
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil || errors.Is(err, ErrCacheMiss) {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		b.probing = false
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
+
+func (b *CircuitBreaker) do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.record(err)
+	return err
+}
+
+func (b *CircuitBreaker) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	err := b.do(func() error {
+		var err error
+		value, err = b.next.Get(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+func (b *CircuitBreaker) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return b.do(func() error { return b.next.Set(ctx, key, value, ttl) })
+}
+
+func (b *CircuitBreaker) Del(ctx context.Context, keys ...string) error {
+	return b.do(func() error { return b.next.Del(ctx, keys...) })
+}
+
+func (b *CircuitBreaker) Exists(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := b.do(func() error {
+		var err error
+		exists, err = b.next.Exists(ctx, key)
+		return err
+	})
+	return exists, err
+}
+
+func (b *CircuitBreaker) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	var ok bool
+	err := b.do(func() error {
+		var err error
+		ok, err = b.next.SetNX(ctx, key, value, ttl)
+		return err
+	})
+	return ok, err
+}
+
+func (b *CircuitBreaker) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	var ok bool
+	err := b.do(func() error {
+		var err error
+		ok, err = b.next.DelIfEqual(ctx, key, value)
+		return err
+	})
+	return ok, err
+}
+
+func (b *CircuitBreaker) ZIncrBy(ctx context.Context, key string, increment float64, member string) error {
+	return b.do(func() error { return b.next.ZIncrBy(ctx, key, increment, member) })
+}
+
+func (b *CircuitBreaker) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]ScoredMember, error) {
+	var members []ScoredMember
+	err := b.do(func() error {
+		var err error
+		members, err = b.next.ZRevRangeWithScores(ctx, key, start, stop)
+		return err
+	})
+	return members, err
+}
+
+func (b *CircuitBreaker) Ping(ctx context.Context) error {
+	return b.do(func() error { return b.next.Ping(ctx) })
+}
+
+func (b *CircuitBreaker) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := b.do(func() error {
+		var err error
+		size, err = b.next.Size(ctx)
+		return err
+	})
+	return size, err
+}
+
+func (b *CircuitBreaker) Close() error {
+	return b.next.Close()
+}
+
+// IsDegraded — breaker로 감싼 캐시가 열려 있으면 true. breaker가 없는 구현은 항상 false.
+func IsDegraded(c Cache) bool {
+	d, ok := c.(interface{ Degraded() bool })
+	return ok && d.Degraded()
+}
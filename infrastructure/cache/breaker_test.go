@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errBackend = errors.New("backend down")
+
+// flakyCache — Get 결과를 테스트에서 바꿀 수 있는 Cache (나머지는 MemoryCache 위임).
+type flakyCache struct {
+	*MemoryCache
+	mu  sync.Mutex
+	err error
+}
+
+func (f *flakyCache) fail(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+func (f *flakyCache) Get(ctx context.Context, key string) (string, error) {
+	f.mu.Lock()
+	err := f.err
+	f.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	return f.MemoryCache.Get(ctx, key)
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	const openTimeout = 20 * time.Millisecond
+	ctx := context.Background()
+
+	type step struct {
+		backendErr error
+		wait       time.Duration
+		wantErr    error
+		wantState  BreakerState
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "stays closed below threshold",
+			steps: []step{
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+			},
+		},
+		{
+			name: "success resets failure count",
+			steps: []step{
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+				{wantErr: ErrCacheMiss, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+			},
+		},
+		{
+			name: "opens at threshold and short-circuits",
+			steps: []step{
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerOpen},
+				{wantErr: ErrCircuitOpen, wantState: BreakerOpen},
+			},
+		},
+		{
+			name: "half-open probe success closes",
+			steps: []step{
+				{backendErr: errBackend, wantErr: errBackend},
+				{backendErr: errBackend, wantErr: errBackend},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerOpen},
+				{wait: 2 * openTimeout, wantErr: ErrCacheMiss, wantState: BreakerClosed},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerClosed},
+			},
+		},
+		{
+			name: "half-open probe failure reopens",
+			steps: []step{
+				{backendErr: errBackend, wantErr: errBackend},
+				{backendErr: errBackend, wantErr: errBackend},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerOpen},
+				{wait: 2 * openTimeout, backendErr: errBackend, wantErr: errBackend, wantState: BreakerOpen},
+				{wantErr: ErrCircuitOpen, wantState: BreakerOpen},
+			},
+		},
+		{
+			name: "canceled probe does not reopen",
+			steps: []step{
+				{backendErr: errBackend, wantErr: errBackend},
+				{backendErr: errBackend, wantErr: errBackend},
+				{backendErr: errBackend, wantErr: errBackend, wantState: BreakerOpen},
+				{wait: 2 * openTimeout, backendErr: context.Canceled, wantErr: context.Canceled, wantState: BreakerHalfOpen},
+				{wantErr: ErrCacheMiss, wantState: BreakerClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &flakyCache{MemoryCache: NewMemoryCache()}
+			breaker := NewCircuitBreaker(backend, 3, openTimeout)
+			for i, s := range tt.steps {
+				if s.wait > 0 {
+					time.Sleep(s.wait)
+					if got := breaker.State(); got != BreakerHalfOpen {
+						t.Fatalf("step %d: State after open timeout = %s, want %s", i, got, BreakerHalfOpen)
+					}
+				}
+				backend.fail(s.backendErr)
+				_, err := breaker.Get(ctx, "k")
+				if !errors.Is(err, s.wantErr) {
+					t.Fatalf("step %d: Get error = %v, want %v", i, err, s.wantErr)
+				}
+				if s.wantState != "" {
+					if got := breaker.State(); got != s.wantState {
+						t.Fatalf("step %d: State = %s, want %s", i, got, s.wantState)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	ctx := context.Background()
+	backend := &flakyCache{MemoryCache: NewMemoryCache()}
+	breaker := NewCircuitBreaker(backend, 1, time.Millisecond)
+
+	backend.fail(errBackend)
+	breaker.Get(ctx, "k")
+	time.Sleep(5 * time.Millisecond)
+
+	// probe 진행 중에는 다른 호출이 백엔드까지 가지 않아야 함
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("first allow after timeout = %v, want nil", err)
+	}
+	if err := breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second allow during probe = %v, want ErrCircuitOpen", err)
+	}
+	if !breaker.Degraded() || !IsDegraded(breaker) {
+		t.Fatal("Degraded during probe = false, want true")
+	}
+	breaker.record(nil)
+	if breaker.Degraded() {
+		t.Fatal("Degraded after successful probe = true, want false")
+	}
+	if IsDegraded(backend) {
+		t.Fatal("IsDegraded(non-breaker) = true, want false")
+	}
+}
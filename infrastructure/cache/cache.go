@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	DriverRedis  = "redis"
+	DriverMemory = "memory"
+)
+
+// ErrCacheMiss — 키가 없거나 만료됨 (redis.Nil 대응).
+var ErrCacheMiss = errors.New("cache: key not found")
+
+// ScoredMember — 정렬 집합(sorted set) 조회 결과 한 건.
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
+// Cache — 상품 캐시/랭킹에 필요한 최소 연산 집합. Redis 또는 인메모리 구현을 config로 선택.
+type Cache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	// SetNX — 키가 없을 때만 저장 (lease/락 점유). 저장했으면 true.
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// DelIfEqual — 값이 value일 때만 삭제 (자기가 점유한 lease만 해제). 삭제했으면 true.
+	DelIfEqual(ctx context.Context, key, value string) (bool, error)
+
+	ZIncrBy(ctx context.Context, key string, increment float64, member string) error
+	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]ScoredMember, error)
+
+	Ping(ctx context.Context) error
+	Size(ctx context.Context) (int64, error)
+	Close() error
+}
@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// MemoryCache — 프로세스 메모리 기반 Cache 구현 (Redis 없이 기동/테스트용, 인스턴스 간 공유 안 됨).
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+	zsets   map[string]map[string]float64
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		zsets:   make(map[string]map[string]float64),
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok {
+		return "", ErrCacheMiss
+	}
+	if entry.expired(time.Now()) {
+		c.mu.Lock()
+		if current, ok := c.entries[key]; ok && current.expired(time.Now()) {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		return "", ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: string(value)}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *MemoryCache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	for _, key := range keys {
+		delete(c.entries, key)
+		delete(c.zsets, key)
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	if _, err := c.Get(ctx, key); err != nil {
+		if err == ErrCacheMiss {
+			c.mu.RLock()
+			_, ok := c.zsets[key]
+			c.mu.RUnlock()
+			return ok, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *MemoryCache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && !entry.expired(now) {
+		return false, nil
+	}
+	entry := memoryEntry{value: string(value)}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	c.entries[key] = entry
+	return true, nil
+}
+
+func (c *MemoryCache) DelIfEqual(ctx context.Context, key, value string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || entry.expired(time.Now()) || entry.value != value {
+		return false, nil
+	}
+	delete(c.entries, key)
+	return true, nil
+}
+
+func (c *MemoryCache) ZIncrBy(ctx context.Context, key string, increment float64, member string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	set, ok := c.zsets[key]
+	if !ok {
+		set = make(map[string]float64)
+		c.zsets[key] = set
+	}
+	set[member] += increment
+	return nil
+}
+
+// ZRevRangeWithScores — Redis ZREVRANGE와 같은 인덱스 규칙 (음수 인덱스는 끝에서부터).
+func (c *MemoryCache) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ([]ScoredMember, error) {
+	c.mu.RLock()
+	set := c.zsets[key]
+	members := make([]ScoredMember, 0, len(set))
+	for member, score := range set {
+		members = append(members, ScoredMember{Member: member, Score: score})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Score != members[j].Score {
+			return members[i].Score > members[j].Score
+		}
+		return members[i].Member > members[j].Member
+	})
+
+	n := int64(len(members))
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= n {
+		stop = n - 1
+	}
+	if n == 0 || start > stop {
+		return []ScoredMember{}, nil
+	}
+	return members[start : stop+1], nil
+}
+
+func (c *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (c *MemoryCache) Size(ctx context.Context) (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return int64(len(c.entries) + len(c.zsets)), nil
+}
+
+func (c *MemoryCache) Close() error {
+	return nil
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		setup   func(c *MemoryCache)
+		key     string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "missing key",
+			setup:   func(c *MemoryCache) {},
+			key:     "k",
+			wantErr: ErrCacheMiss,
+		},
+		{
+			name:  "set then get",
+			setup: func(c *MemoryCache) { c.Set(ctx, "k", []byte("v"), 0) },
+			key:   "k",
+			want:  "v",
+		},
+		{
+			name: "overwrite",
+			setup: func(c *MemoryCache) {
+				c.Set(ctx, "k", []byte("v1"), 0)
+				c.Set(ctx, "k", []byte("v2"), time.Minute)
+			},
+			key:  "k",
+			want: "v2",
+		},
+		{
+			name: "deleted",
+			setup: func(c *MemoryCache) {
+				c.Set(ctx, "k", []byte("v"), 0)
+				c.Del(ctx, "other", "k")
+			},
+			key:     "k",
+			wantErr: ErrCacheMiss,
+		},
+		{
+			name: "expired",
+			setup: func(c *MemoryCache) {
+				c.entries["k"] = memoryEntry{value: "v", expiresAt: time.Now().Add(-time.Second)}
+			},
+			key:     "k",
+			wantErr: ErrCacheMiss,
+		},
+		{
+			name:  "not yet expired",
+			setup: func(c *MemoryCache) { c.Set(ctx, "k", []byte("v"), time.Minute) },
+			key:   "k",
+			want:  "v",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache()
+			tt.setup(c)
+			got, err := c.Get(ctx, tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Get = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+	if err := c.Set(ctx, "k", []byte("v"), 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := c.Exists(ctx, "k"); !ok {
+		t.Fatal("Exists before ttl = false, want true")
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, err := c.Get(ctx, "k"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get after ttl error = %v, want ErrCacheMiss", err)
+	}
+	if ok, _ := c.Exists(ctx, "k"); ok {
+		t.Fatal("Exists after ttl = true, want false")
+	}
+	if size, _ := c.Size(ctx); size != 0 {
+		t.Fatalf("Size after expired Get = %d, want 0", size)
+	}
+}
+
+func TestMemoryCacheSetNX(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+	if ok, _ := c.SetNX(ctx, "lock", []byte("a"), time.Minute); !ok {
+		t.Fatal("first SetNX = false, want true")
+	}
+	if ok, _ := c.SetNX(ctx, "lock", []byte("b"), time.Minute); ok {
+		t.Fatal("second SetNX = true, want false")
+	}
+	c.entries["lock"] = memoryEntry{value: "a", expiresAt: time.Now().Add(-time.Second)}
+	if ok, _ := c.SetNX(ctx, "lock", []byte("b"), time.Minute); !ok {
+		t.Fatal("SetNX over expired = false, want true")
+	}
+	if got, _ := c.Get(ctx, "lock"); got != "b" {
+		t.Fatalf("Get = %q, want %q", got, "b")
+	}
+}
+
+func TestMemoryCacheDelIfEqual(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		entry   *memoryEntry
+		value   string
+		want    bool
+		wantHit bool
+	}{
+		{name: "missing", value: "a"},
+		{name: "equal", entry: &memoryEntry{value: "a"}, value: "a", want: true},
+		{name: "different", entry: &memoryEntry{value: "a"}, value: "b", wantHit: true},
+		{name: "expired", entry: &memoryEntry{value: "a", expiresAt: time.Now().Add(-time.Second)}, value: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache()
+			if tt.entry != nil {
+				c.entries["k"] = *tt.entry
+			}
+			ok, err := c.DelIfEqual(ctx, "k", tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.want {
+				t.Fatalf("DelIfEqual = %v, want %v", ok, tt.want)
+			}
+			if _, err := c.Get(ctx, "k"); (err == nil) != tt.wantHit {
+				t.Fatalf("Get after DelIfEqual error = %v, want hit %v", err, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheZRevRange(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+	c.ZIncrBy(ctx, "rank", 3, "a")
+	c.ZIncrBy(ctx, "rank", 5, "b")
+	c.ZIncrBy(ctx, "rank", 1, "c")
+	c.ZIncrBy(ctx, "rank", 1, "a")
+
+	tests := []struct {
+		name        string
+		start, stop int64
+		want        []string
+	}{
+		{name: "all", start: 0, stop: -1, want: []string{"b", "a", "c"}},
+		{name: "top two", start: 0, stop: 1, want: []string{"b", "a"}},
+		{name: "negative start", start: -1, stop: -1, want: []string{"c"}},
+		{name: "stop past end", start: 1, stop: 10, want: []string{"a", "c"}},
+		{name: "empty range", start: 2, stop: 1, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.ZRevRangeWithScores(ctx, "rank", tt.start, tt.stop)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i].Member != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if ok, _ := c.Exists(ctx, "rank"); !ok {
+		t.Fatal("Exists(zset) = false, want true")
+	}
+	c.Del(ctx, "rank")
+	if got, _ := c.ZRevRangeWithScores(ctx, "rank", 0, -1); len(got) != 0 {
+		t.Fatalf("after Del got %v, want empty", got)
+	}
+}
@@ -0,0 +1,159 @@
+package kafkamonitor
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	messagesConsumed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "messages_consumed_total",
+			Help:      "Kafka messages handled by topic and outcome (ok, duplicate, rejected, retried, dlq, decode_error)",
+		},
+		[]string{"topic", "outcome"},
+	)
+	processingDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "message_processing_duration_seconds",
+			Help:      "Kafka message processing duration in seconds, by topic and outcome",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+		[]string{"topic", "outcome"},
+	)
+	messagesProduced = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "messages_produced_total",
+			Help:      "Kafka messages published by topic",
+		},
+		[]string{"topic"},
+	)
+	produceErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "produce_errors_total",
+			Help:      "Kafka publish failures by topic",
+		},
+		[]string{"topic"},
+	)
+	consumerLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "consumer_lag",
+			Help:      "Messages behind the partition high watermark, by topic, consumer group and partition",
+		},
+		[]string{"topic", "group", "partition"},
+	)
+	readerErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "reader_errors_total",
+			Help:      "Kafka reader fetch errors reported by Reader.Stats()",
+		},
+		[]string{"topic", "group"},
+	)
+	readerRebalances = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "kafka",
+			Name:      "reader_rebalances_total",
+			Help:      "Consumer group rebalances reported by Reader.Stats()",
+		},
+		[]string{"topic", "group"},
+	)
+)
+
+// ObserveProcessing — 메시지 한 건 처리 시간 기록 (결과 건수는 Record).
+func (m *Monitor) ObserveProcessing(topic, outcome string, d time.Duration) {
+	processingDuration.WithLabelValues(topic, outcome).Observe(d.Seconds())
+}
+
+// RecordProduced — 발행 성공/실패 1건 기록. nil Monitor도 허용 (CLI 등).
+func (m *Monitor) RecordProduced(topic string, err error) {
+	if err != nil {
+		produceErrors.WithLabelValues(topic).Inc()
+	} else {
+		messagesProduced.WithLabelValues(topic).Inc()
+	}
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.produced == nil {
+		m.produced = make(map[string]int64)
+	}
+	if err != nil {
+		m.produced[CounterKey(topic, "error")]++
+	} else {
+		m.produced[CounterKey(topic, "ok")]++
+	}
+}
+
+func (m *Monitor) ProducedSnapshot() map[string]int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snap := make(map[string]int64, len(m.produced))
+	for k, v := range m.produced {
+		snap[k] = v
+	}
+	return snap
+}
+
+// ObserveLag — 읽은 메시지의 HighWaterMark 기준 파티션별 lag.
+func (m *Monitor) ObserveLag(group string, msg kafka.Message) {
+	if msg.HighWaterMark <= 0 {
+		return
+	}
+	lag := msg.HighWaterMark - msg.Offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+	partition := strconv.Itoa(msg.Partition)
+	consumerLag.WithLabelValues(msg.Topic, group, partition).Set(float64(lag))
+
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lag == nil {
+		m.lag = make(map[string]int64)
+	}
+	m.lag[msg.Topic+"/"+partition] = lag
+}
+
+// ObserveReaderStats — Reader.Stats() 주기 수집. Stats()는 호출 사이 증가분을 반환.
+// 컨슈머 그룹 reader의 Stats().Lag는 마지막으로 읽은 파티션 값뿐이라 그룹 없는 reader만 lag 기록
+// (그룹 reader는 ObserveLag로 파티션별 기록).
+func (m *Monitor) ObserveReaderStats(group string, stats kafka.ReaderStats) {
+	readerErrors.WithLabelValues(stats.Topic, group).Add(float64(stats.Errors))
+	readerRebalances.WithLabelValues(stats.Topic, group).Add(float64(stats.Rebalances))
+	if group == "" {
+		consumerLag.WithLabelValues(stats.Topic, group, stats.Partition).Set(float64(stats.Lag))
+	}
+}
+
+func (m *Monitor) LagSnapshot() map[string]int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snap := make(map[string]int64, len(m.lag))
+	for k, v := range m.lag {
+		snap[k] = v
+	}
+	return snap
+}
@@ -1,85 +1,47 @@
 package kafkamonitor
 
 import (
+	"strings"
 	"sync"
 )
 
-// Monitor — Kafka 컨슈머 처리 건수 (프로세스 메모리, 디버그용).
+// Monitor — Kafka 토픽/결과별 처리·발행 건수와 lag (프로세스 메모리, 디버그용).
+// 같은 값을 Prometheus 메트릭으로도 내보냄 (metrics.go).
 type Monitor struct {
-	mu               sync.RWMutex
-	StockUpdatedOK   int64 `json:"stock_updated_ok"`
-	StockUpdatedDup  int64 `json:"stock_updated_duplicate_skipped"`
-	StockUpdatedDLQ  int64 `json:"stock_updated_dlq"`
-	RollbackOK       int64 `json:"stock_rollback_ok"`
-	RollbackDup      int64 `json:"stock_rollback_duplicate_skipped"`
-	RollbackDLQ      int64 `json:"stock_rollback_dlq"`
-	UnmarshalErrors  int64 `json:"unmarshal_errors"`
-	SchemaRejected   int64 `json:"schema_version_rejected"`
-}
-
-func NewMonitor() *Monitor {
-	return &Monitor{}
-}
-
-func (m *Monitor) IncStockUpdatedOK() {
-	m.mu.Lock()
-	m.StockUpdatedOK++
-	m.mu.Unlock()
-}
-
-func (m *Monitor) IncStockUpdatedDup() {
-	m.mu.Lock()
-	m.StockUpdatedDup++
-	m.mu.Unlock()
-}
-
-func (m *Monitor) IncStockUpdatedDLQ() {
-	m.mu.Lock()
-	m.StockUpdatedDLQ++
-	m.mu.Unlock()
-}
+	mu     sync.RWMutex
+	counts map[string]int64
 
-func (m *Monitor) IncRollbackOK() {
-	m.mu.Lock()
-	m.RollbackOK++
-	m.mu.Unlock()
-}
-
-func (m *Monitor) IncRollbackDup() {
-	m.mu.Lock()
-	m.RollbackDup++
-	m.mu.Unlock()
+	consumers map[string]ConsumerStatus
+	produced  map[string]int64
+	lag       map[string]int64
 }
 
-func (m *Monitor) IncRollbackDLQ() {
-	m.mu.Lock()
-	m.RollbackDLQ++
-	m.mu.Unlock()
+func NewMonitor() *Monitor {
+	return &Monitor{counts: make(map[string]int64)}
 }
 
-func (m *Monitor) IncUnmarshalErr() {
-	m.mu.Lock()
-	m.UnmarshalErrors++
-	m.mu.Unlock()
+// CounterKey — "stock.updated" + "ok" → "stock_updated_ok".
+func CounterKey(topic, outcome string) string {
+	return strings.ReplaceAll(topic, ".", "_") + "_" + outcome
 }
 
-func (m *Monitor) IncSchemaRejected() {
+// Record — 토픽별 처리 결과(ok, duplicate, rejected, retried, dlq, decode_error) 1건 기록.
+func (m *Monitor) Record(topic, outcome string) {
+	messagesConsumed.WithLabelValues(topic, outcome).Inc()
+	if m == nil {
+		return
+	}
 	m.mu.Lock()
-	m.SchemaRejected++
+	m.counts[CounterKey(topic, outcome)]++
 	m.mu.Unlock()
 }
 
 func (m *Monitor) Snapshot() map[string]int64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return map[string]int64{
-		"stock_updated_ok":                 m.StockUpdatedOK,
-		"stock_updated_duplicate_skipped":    m.StockUpdatedDup,
-		"stock_updated_dlq":                m.StockUpdatedDLQ,
-		"stock_rollback_ok":                m.RollbackOK,
-		"stock_rollback_duplicate_skipped": m.RollbackDup,
-		"stock_rollback_dlq":               m.RollbackDLQ,
-		"unmarshal_errors":                 m.UnmarshalErrors,
-		"schema_version_rejected":          m.SchemaRejected,
+	snap := make(map[string]int64, len(m.counts))
+	for k, v := range m.counts {
+		snap[k] = v
 	}
+	return snap
 }
@@ -0,0 +1,48 @@
+package kafkamonitor
+
+import "time"
+
+const (
+	StateRunning    = "running"
+	StateBackingOff = "backing_off"
+	StateStopped    = "stopped"
+)
+
+// ConsumerStatus — 컨슈머 루프 상태 (/debug/kafka 노출용).
+type ConsumerStatus struct {
+	State               string    `json:"state"`
+	Since               time.Time `json:"since"`
+	ConsecutiveFailures int       `json:"consecutive_failures,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+}
+
+func (m *Monitor) SetConsumerState(name, state string, err error, failures int) {
+	status := ConsumerStatus{
+		State:               state,
+		Since:               time.Now().UTC(),
+		ConsecutiveFailures: failures,
+	}
+	if err != nil {
+		status.LastError = err.Error()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.consumers == nil {
+		m.consumers = make(map[string]ConsumerStatus)
+	}
+	if prev, ok := m.consumers[name]; ok && prev.State == state {
+		status.Since = prev.Since
+	}
+	m.consumers[name] = status
+}
+
+func (m *Monitor) ConsumerStates() map[string]ConsumerStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	states := make(map[string]ConsumerStatus, len(m.consumers))
+	for name, status := range m.consumers {
+		states[name] = status
+	}
+	return states
+}
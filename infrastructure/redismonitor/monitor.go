@@ -2,9 +2,8 @@ package redismonitor
 
 import (
 	"context"
+	"productfc/infrastructure/cache"
 	"sync"
-
-	"github.com/redis/go-redis/v9"
 )
 
 type RedisStats struct {
@@ -27,12 +26,12 @@ type Monitor struct {
 	misses   int64
 	totalOps int64
 	errors   int64
-	redis    *redis.Client
+	cache    cache.Cache
 }
 
-func NewMonitor(client *redis.Client) *Monitor {
+func NewMonitor(c cache.Cache) *Monitor {
 	return &Monitor{
-		redis: client,
+		cache: c,
 	}
 }
 
@@ -78,7 +77,7 @@ func (m *Monitor) GetDebugInfo(ctx context.Context) RedisStats {
 		stats.HitRate = float64(stats.Hits) / float64(total) * 100
 	}
 
-	dbSize, err := m.redis.DBSize(ctx).Result()
+	dbSize, err := m.cache.Size(ctx)
 	if err == nil {
 		stats.Keys = []KeyInfo{
 			{Pattern: "total_keys", Count: dbSize},
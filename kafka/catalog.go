@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/schema"
+	"productfc/models"
+	"strconv"
+)
+
+// PublishProductEvent — product.* 이벤트와 product.snapshot 최신 상태를 상품 ID 키로 발행.
+// 삭제면 snapshot에 tombstone(값 nil)을 보내 compaction 후 키가 사라지게 함.
+// snapshot은 이벤트가 아닌 상태라 CloudEvents 없이 상품 JSON만 발행.
+func (p *Producer) PublishProductEvent(ctx context.Context, event models.ProductChangedEvent) error {
+	topic, err := p.productTopic(event.EventType)
+	if err != nil {
+		return err
+	}
+	id := strconv.FormatInt(event.ProductID, 10)
+	key := []byte(id)
+	err = p.publishEvent(ctx, topic, key, schema.ProductChanged, cloudevents.Event{
+		Type:    p.events.Type(event.EventType),
+		Subject: id,
+		Time:    event.EventTime,
+	}, event)
+	if err != nil {
+		return err
+	}
+
+	var snapshot []byte
+	if event.After != nil {
+		if snapshot, err = json.Marshal(event.After); err != nil {
+			return err
+		}
+	}
+	return p.PublishRaw(ctx, p.topics.ProductSnapshot, key, snapshot)
+}
+
+// PublishCategoryEvent — category.* 이벤트를 카테고리 ID 키로 발행.
+func (p *Producer) PublishCategoryEvent(ctx context.Context, event models.CategoryChangedEvent) error {
+	topic, err := p.categoryTopic(event.EventType)
+	if err != nil {
+		return err
+	}
+	id := strconv.Itoa(event.CategoryID)
+	return p.publishEvent(ctx, topic, []byte(id), schema.CategoryChanged, cloudevents.Event{
+		Type:    p.events.Type(event.EventType),
+		Subject: id,
+		Time:    event.EventTime,
+	}, event)
+}
+
+func (p *Producer) productTopic(eventType string) (string, error) {
+	switch eventType {
+	case models.ProductEventCreated:
+		return p.topics.ProductCreated, nil
+	case models.ProductEventUpdated:
+		return p.topics.ProductUpdated, nil
+	case models.ProductEventDeleted:
+		return p.topics.ProductDeleted, nil
+	}
+	return "", fmt.Errorf("unknown product event type %q", eventType)
+}
+
+func (p *Producer) categoryTopic(eventType string) (string, error) {
+	switch eventType {
+	case models.CategoryEventCreated:
+		return p.topics.CategoryCreated, nil
+	case models.CategoryEventUpdated:
+		return p.topics.CategoryUpdated, nil
+	case models.CategoryEventDeleted:
+		return p.topics.CategoryDeleted, nil
+	}
+	return "", fmt.Errorf("unknown category event type %q", eventType)
+}
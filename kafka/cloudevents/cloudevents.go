@@ -0,0 +1,314 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"productfc/config"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+// CloudEvents 1.0 Kafka protocol binding.
+// binary: 본문은 이벤트 JSON 그대로, 속성은 ce_* 헤더 (레거시 소비자도 본문을 그대로 읽을 수 있음).
+// structured: 본문 전체가 application/cloudevents+json 봉투 (data에 이벤트 JSON).
+const (
+	SpecVersion = "1.0"
+
+	ModeNone       = "none"
+	ModeBinary     = "binary"
+	ModeStructured = "structured"
+
+	HeaderPrefix      = "ce_"
+	HeaderContentType = "content-type"
+
+	ContentTypeJSON       = "application/json"
+	ContentTypeStructured = "application/cloudevents+json"
+
+	DefaultSource     = "/productfc"
+	DefaultTypePrefix = "com.gocommerce."
+)
+
+const (
+	headerSpecVersion = HeaderPrefix + "specversion"
+	headerID          = HeaderPrefix + "id"
+	headerSource      = HeaderPrefix + "source"
+	headerType        = HeaderPrefix + "type"
+	headerSubject     = HeaderPrefix + "subject"
+	headerTime        = HeaderPrefix + "time"
+	headerDataSchema  = HeaderPrefix + "dataschema"
+)
+
+// Event — CloudEvents 컨텍스트 속성. 발행 시 ID/Source/Time/SpecVersion은 비어 있으면 Encoder가 채움.
+type Event struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	// Mode — 수신 메시지의 인코딩 (binary | structured).
+	Mode string
+}
+
+// envelope — structured mode 본문.
+type envelope struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      []byte          `json:"data_base64,omitempty"`
+}
+
+// Encoder — 토픽별 인코딩 모드를 적용해 발행 메시지를 CloudEvents로 변환. nil이면 레거시(none).
+type Encoder struct {
+	source     string
+	typePrefix string
+	mode       string
+	modes      map[string]string
+}
+
+// NewEncoder — topics는 설정 이름(order_created 등) → 실제 토픽명. cfg.Topics는 같은 이름으로 모드 재정의.
+func NewEncoder(cfg config.KafkaCloudEventsConfig, topics map[string]string) (*Encoder, error) {
+	e := &Encoder{
+		source:     cfg.Source,
+		typePrefix: cfg.TypePrefix,
+		mode:       cfg.Mode,
+		modes:      make(map[string]string, len(cfg.Topics)),
+	}
+	if e.source == "" {
+		e.source = DefaultSource
+	}
+	if e.typePrefix == "" {
+		e.typePrefix = DefaultTypePrefix
+	}
+	if e.mode == "" {
+		e.mode = ModeBinary
+	}
+	if err := validMode(e.mode); err != nil {
+		return nil, err
+	}
+	for name, mode := range cfg.Topics {
+		topic, ok := topics[name]
+		if !ok {
+			return nil, fmt.Errorf("cloudevents: unknown topic name %q", name)
+		}
+		if err := validMode(mode); err != nil {
+			return nil, err
+		}
+		e.modes[topic] = mode
+	}
+	return e, nil
+}
+
+func validMode(mode string) error {
+	switch mode {
+	case ModeNone, ModeBinary, ModeStructured:
+		return nil
+	}
+	return fmt.Errorf("cloudevents: unsupported mode %q (none | binary | structured)", mode)
+}
+
+// Mode — 토픽의 발행 인코딩.
+func (e *Encoder) Mode(topic string) string {
+	if e == nil {
+		return ModeNone
+	}
+	if mode, ok := e.modes[topic]; ok {
+		return mode
+	}
+	return e.mode
+}
+
+// Type — 이벤트 이름(기본 토픽명, 예: stock.reserved)에 접두사를 붙인 CloudEvents type.
+func (e *Encoder) Type(name string) string {
+	if e == nil {
+		return DefaultTypePrefix + name
+	}
+	return e.typePrefix + name
+}
+
+// Encode — msg.Value(이벤트 JSON)를 topic의 모드로 인코딩. 기존 ce_*/content-type 헤더는 교체.
+// topic은 writer에 토픽이 고정된 경우(msg.Topic 비어 있음)를 위해 따로 받음.
+func (e *Encoder) Encode(topic string, msg *kafka.Message, ev Event) error {
+	mode := e.Mode(topic)
+	if mode == ModeNone {
+		return nil
+	}
+
+	if ev.SpecVersion == "" {
+		ev.SpecVersion = SpecVersion
+	}
+	if ev.ID == "" {
+		ev.ID = uuid.NewString()
+	}
+	if ev.Source == "" {
+		ev.Source = e.source
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	if ev.DataContentType == "" {
+		ev.DataContentType = ContentTypeJSON
+	}
+	if ev.Type == "" {
+		return fmt.Errorf("cloudevents: type is required (topic %s)", topic)
+	}
+
+	headers := msg.Headers[:0:0]
+	for _, h := range msg.Headers {
+		if !IsHeader(h.Key) {
+			headers = append(headers, h)
+		}
+	}
+
+	switch mode {
+	case ModeBinary:
+		headers = append(headers,
+			kafka.Header{Key: headerSpecVersion, Value: []byte(ev.SpecVersion)},
+			kafka.Header{Key: headerID, Value: []byte(ev.ID)},
+			kafka.Header{Key: headerSource, Value: []byte(ev.Source)},
+			kafka.Header{Key: headerType, Value: []byte(ev.Type)},
+			kafka.Header{Key: headerTime, Value: []byte(ev.Time.UTC().Format(time.RFC3339Nano))},
+			kafka.Header{Key: HeaderContentType, Value: []byte(ev.DataContentType)},
+		)
+		if ev.Subject != "" {
+			headers = append(headers, kafka.Header{Key: headerSubject, Value: []byte(ev.Subject)})
+		}
+		if ev.DataSchema != "" {
+			headers = append(headers, kafka.Header{Key: headerDataSchema, Value: []byte(ev.DataSchema)})
+		}
+	case ModeStructured:
+		t := ev.Time.UTC()
+		env := envelope{
+			SpecVersion:     ev.SpecVersion,
+			ID:              ev.ID,
+			Source:          ev.Source,
+			Type:            ev.Type,
+			Subject:         ev.Subject,
+			Time:            &t,
+			DataContentType: ev.DataContentType,
+			DataSchema:      ev.DataSchema,
+		}
+		if isJSON(ev.DataContentType) && json.Valid(msg.Value) {
+			env.Data = msg.Value
+		} else {
+			env.DataBase64 = msg.Value
+		}
+		b, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+		msg.Value = b
+		headers = append(headers, kafka.Header{Key: HeaderContentType, Value: []byte(ContentTypeStructured)})
+	}
+	msg.Headers = headers
+	return nil
+}
+
+// Unwrap — 수신 메시지에서 이벤트 본문과 CloudEvents 속성을 꺼냄.
+// ce_specversion 헤더면 binary, content-type이 application/cloudevents+json이면 structured,
+// 둘 다 아니면 레거시 본문으로 보고 (본문, nil) 반환.
+func Unwrap(msg kafka.Message) ([]byte, *Event, error) {
+	if specVersion := header(msg, headerSpecVersion); specVersion != "" {
+		ev := &Event{
+			SpecVersion:     specVersion,
+			ID:              header(msg, headerID),
+			Source:          header(msg, headerSource),
+			Type:            header(msg, headerType),
+			Subject:         header(msg, headerSubject),
+			DataContentType: header(msg, HeaderContentType),
+			DataSchema:      header(msg, headerDataSchema),
+			Mode:            ModeBinary,
+		}
+		if raw := header(msg, headerTime); raw != "" {
+			t, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cloudevents: invalid ce_time %q: %w", raw, err)
+			}
+			ev.Time = t
+		}
+		if err := ev.validate(); err != nil {
+			return nil, nil, err
+		}
+		return msg.Value, ev, nil
+	}
+
+	if !strings.HasPrefix(header(msg, HeaderContentType), ContentTypeStructured) {
+		return msg.Value, nil, nil
+	}
+	var env envelope
+	if err := json.Unmarshal(msg.Value, &env); err != nil {
+		return nil, nil, fmt.Errorf("cloudevents: invalid structured event: %w", err)
+	}
+	ev := &Event{
+		SpecVersion:     env.SpecVersion,
+		ID:              env.ID,
+		Source:          env.Source,
+		Type:            env.Type,
+		Subject:         env.Subject,
+		DataContentType: env.DataContentType,
+		DataSchema:      env.DataSchema,
+		Mode:            ModeStructured,
+	}
+	if env.Time != nil {
+		ev.Time = *env.Time
+	}
+	if err := ev.validate(); err != nil {
+		return nil, nil, err
+	}
+	if env.DataBase64 != nil {
+		return env.DataBase64, ev, nil
+	}
+	return env.Data, ev, nil
+}
+
+// DataOf — structured 봉투면 data, 아니면 본문 그대로 (DLQ 원문에서 주문 ID 추출 등).
+func DataOf(body []byte) []byte {
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil || env.SpecVersion == "" {
+		return body
+	}
+	if env.DataBase64 != nil {
+		return env.DataBase64
+	}
+	return env.Data
+}
+
+func (ev *Event) validate() error {
+	if ev.SpecVersion != SpecVersion {
+		return fmt.Errorf("cloudevents: unsupported specversion %q", ev.SpecVersion)
+	}
+	if ev.ID == "" || ev.Source == "" || ev.Type == "" {
+		return fmt.Errorf("cloudevents: id, source and type are required")
+	}
+	return nil
+}
+
+// IsHeader — CloudEvents binary mode 헤더 (ce_* 와 content-type).
+func IsHeader(key string) bool {
+	return strings.HasPrefix(key, HeaderPrefix) || key == HeaderContentType
+}
+
+func isJSON(contentType string) bool {
+	return contentType == ContentTypeJSON || strings.HasSuffix(contentType, "+json")
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
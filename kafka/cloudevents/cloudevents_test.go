@@ -0,0 +1,236 @@
+package cloudevents
+
+import (
+	"testing"
+	"time"
+
+	"productfc/config"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var testTopics = map[string]string{
+	"stock_reserved": "stock.reserved",
+	"stock_changed":  "stock.changed",
+}
+
+func TestEncodeUnwrapRoundTrip(t *testing.T) {
+	eventTime := time.Date(2026, 10, 19, 9, 30, 0, 123456789, time.FixedZone("KST", 9*60*60))
+	tests := []struct {
+		name       string
+		mode       string
+		value      []byte
+		event      Event
+		wantHeader string // content-type 헤더
+	}{
+		{
+			name:       "binary",
+			mode:       ModeBinary,
+			value:      []byte(`{"order_id":1}`),
+			event:      Event{ID: "evt-1", Type: "com.gocommerce.stock.reserved", Subject: "1", Time: eventTime, DataSchema: "stock.reserved.v1"},
+			wantHeader: ContentTypeJSON,
+		},
+		{
+			name:       "structured json data",
+			mode:       ModeStructured,
+			value:      []byte(`{"order_id":1}`),
+			event:      Event{ID: "evt-2", Type: "com.gocommerce.stock.reserved", Subject: "1", Time: eventTime, DataSchema: "stock.reserved.v1"},
+			wantHeader: ContentTypeStructured,
+		},
+		{
+			name:       "structured binary data",
+			mode:       ModeStructured,
+			value:      []byte{0x0a, 0x02, 0xff, 0x00},
+			event:      Event{ID: "evt-3", Type: "com.gocommerce.stock.reserved", Time: eventTime, DataContentType: "application/x-protobuf"},
+			wantHeader: ContentTypeStructured,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, err := NewEncoder(config.KafkaCloudEventsConfig{Mode: tt.mode}, testTopics)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg := kafka.Message{
+				Value: append([]byte(nil), tt.value...),
+				Headers: []kafka.Header{
+					{Key: "x-trace", Value: []byte("abc")},
+					{Key: headerID, Value: []byte("stale")},
+				},
+			}
+			if err := enc.Encode("stock.reserved", &msg, tt.event); err != nil {
+				t.Fatal(err)
+			}
+			if got := header(msg, HeaderContentType); got != tt.wantHeader {
+				t.Fatalf("content-type = %q, want %q", got, tt.wantHeader)
+			}
+			if got := header(msg, "x-trace"); got != "abc" {
+				t.Fatalf("non-CloudEvents header lost: x-trace = %q", got)
+			}
+
+			body, ev, err := Unwrap(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != string(tt.value) {
+				t.Fatalf("body = %q, want %q", body, tt.value)
+			}
+			if ev == nil {
+				t.Fatal("Unwrap returned no event")
+			}
+			wantContentType := tt.event.DataContentType
+			if wantContentType == "" {
+				wantContentType = ContentTypeJSON
+			}
+			switch {
+			case ev.Mode != tt.mode:
+				t.Fatalf("Mode = %q, want %q", ev.Mode, tt.mode)
+			case ev.SpecVersion != SpecVersion:
+				t.Fatalf("SpecVersion = %q, want %q", ev.SpecVersion, SpecVersion)
+			case ev.ID != tt.event.ID:
+				t.Fatalf("ID = %q, want %q", ev.ID, tt.event.ID)
+			case ev.Source != DefaultSource:
+				t.Fatalf("Source = %q, want %q", ev.Source, DefaultSource)
+			case ev.Type != tt.event.Type:
+				t.Fatalf("Type = %q, want %q", ev.Type, tt.event.Type)
+			case ev.Subject != tt.event.Subject:
+				t.Fatalf("Subject = %q, want %q", ev.Subject, tt.event.Subject)
+			case ev.DataSchema != tt.event.DataSchema:
+				t.Fatalf("DataSchema = %q, want %q", ev.DataSchema, tt.event.DataSchema)
+			case ev.DataContentType != wantContentType:
+				t.Fatalf("DataContentType = %q, want %q", ev.DataContentType, wantContentType)
+			case !ev.Time.Equal(tt.event.Time):
+				t.Fatalf("Time = %v, want %v", ev.Time, tt.event.Time)
+			}
+		})
+	}
+}
+
+func TestEncodeModePerTopic(t *testing.T) {
+	enc, err := NewEncoder(config.KafkaCloudEventsConfig{
+		Mode:   ModeStructured,
+		Topics: map[string]string{"stock_changed": ModeNone},
+	}, testTopics)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := kafka.Message{Value: []byte(`{"product_id":1}`)}
+	if err := enc.Encode("stock.changed", &msg, Event{Type: "com.gocommerce.stock.changed"}); err != nil {
+		t.Fatal(err)
+	}
+	body, ev, err := Unwrap(msg)
+	if err != nil || ev != nil || string(body) != `{"product_id":1}` {
+		t.Fatalf("none mode Unwrap = (%q, %v, %v), want legacy body", body, ev, err)
+	}
+
+	if got := enc.Mode("stock.reserved"); got != ModeStructured {
+		t.Fatalf("Mode(default) = %q, want %q", got, ModeStructured)
+	}
+	var nilEncoder *Encoder
+	if got := nilEncoder.Mode("stock.reserved"); got != ModeNone {
+		t.Fatalf("nil encoder Mode = %q, want %q", got, ModeNone)
+	}
+}
+
+func TestNewEncoderRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.KafkaCloudEventsConfig
+	}{
+		{name: "unknown mode", cfg: config.KafkaCloudEventsConfig{Mode: "batch"}},
+		{name: "unknown topic", cfg: config.KafkaCloudEventsConfig{Topics: map[string]string{"nope": ModeBinary}}},
+		{name: "unknown topic mode", cfg: config.KafkaCloudEventsConfig{Topics: map[string]string{"stock_changed": "batch"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewEncoder(tt.cfg, testTopics); err == nil {
+				t.Fatal("NewEncoder error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestUnwrapRejectsInvalidEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  kafka.Message
+	}{
+		{
+			name: "binary unsupported specversion",
+			msg: kafka.Message{Headers: []kafka.Header{
+				{Key: headerSpecVersion, Value: []byte("0.3")},
+				{Key: headerID, Value: []byte("1")},
+				{Key: headerSource, Value: []byte("/s")},
+				{Key: headerType, Value: []byte("t")},
+			}},
+		},
+		{
+			name: "binary missing id",
+			msg: kafka.Message{Headers: []kafka.Header{
+				{Key: headerSpecVersion, Value: []byte(SpecVersion)},
+				{Key: headerSource, Value: []byte("/s")},
+				{Key: headerType, Value: []byte("t")},
+			}},
+		},
+		{
+			name: "binary invalid time",
+			msg: kafka.Message{Headers: []kafka.Header{
+				{Key: headerSpecVersion, Value: []byte(SpecVersion)},
+				{Key: headerTime, Value: []byte("yesterday")},
+			}},
+		},
+		{
+			name: "structured invalid json",
+			msg: kafka.Message{
+				Value:   []byte(`{`),
+				Headers: []kafka.Header{{Key: HeaderContentType, Value: []byte(ContentTypeStructured)}},
+			},
+		},
+		{
+			name: "structured missing type",
+			msg: kafka.Message{
+				Value:   []byte(`{"specversion":"1.0","id":"1","source":"/s"}`),
+				Headers: []kafka.Header{{Key: HeaderContentType, Value: []byte(ContentTypeStructured)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Unwrap(tt.msg); err == nil {
+				t.Fatal("Unwrap error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestEncodeRequiresType(t *testing.T) {
+	enc, err := NewEncoder(config.KafkaCloudEventsConfig{}, testTopics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := kafka.Message{Value: []byte(`{}`)}
+	if err := enc.Encode("stock.reserved", &msg, Event{}); err == nil {
+		t.Fatal("Encode without type error = nil, want error")
+	}
+}
+
+func TestDataOf(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "legacy body", body: `{"order_id":1}`, want: `{"order_id":1}`},
+		{name: "structured json", body: `{"specversion":"1.0","id":"1","source":"/s","type":"t","data":{"order_id":1}}`, want: `{"order_id":1}`},
+		{name: "structured base64", body: `{"specversion":"1.0","id":"1","source":"/s","type":"t","data_base64":"AQI="}`, want: "\x01\x02"},
+		{name: "not json", body: "raw", want: "raw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(DataOf([]byte(tt.body))); got != tt.want {
+				t.Fatalf("DataOf = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
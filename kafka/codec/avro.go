@@ -0,0 +1,380 @@
+package codec
+
+import (
+	"fmt"
+
+	"productfc/kafka/codec/eventavro"
+	"productfc/kafka/schema"
+	"productfc/models"
+
+	"github.com/hamba/avro/v2"
+)
+
+// avroCodec — eventavro(*.avsc) 레코드로 직렬화 (스키마 ID 없는 raw binary, 레코드는 이벤트 타입으로 결정).
+type avroCodec struct{}
+
+func (avroCodec) Name() string        { return NameAvro }
+func (avroCodec) ContentType() string { return ContentTypeAvro }
+
+func (avroCodec) Marshal(subject string, event any) ([]byte, error) {
+	if err := schema.Default.Validate(subject, event); err != nil {
+		return nil, err
+	}
+	record, v, err := toAvro(event)
+	if err != nil {
+		return nil, err
+	}
+	s, err := eventavro.Schema(record)
+	if err != nil {
+		return nil, err
+	}
+	return avro.Marshal(s, v)
+}
+
+func (avroCodec) Unmarshal(subject string, data []byte, event any) error {
+	if err := fromAvro(data, event); err != nil {
+		return err
+	}
+	// 이전 버전 프로듀서가 보낸 값도 JSON과 같이 버전별 검증 + 업캐스트
+	return schema.Default.Upcast(subject, event)
+}
+
+func unmarshalAvro(record string, data []byte, v any) error {
+	s, err := eventavro.Schema(record)
+	if err != nil {
+		return err
+	}
+	return avro.Unmarshal(s, data, v)
+}
+
+func toAvro(event any) (string, any, error) {
+	switch e := event.(type) {
+	case models.OrderCreatedEvent:
+		return "OrderCreatedEvent", &eventavro.OrderCreatedEvent{
+			SchemaVersion:   int64(e.SchemaVersion),
+			OrderID:         e.OrderID,
+			UserID:          e.UserID,
+			TotalAmount:     e.TotalAmount,
+			PaymentMethod:   e.PaymentMethod,
+			ShippingAddress: e.ShippingAddress,
+			Products:        productItemsToAvro(e.Products),
+		}, nil
+	case models.ProductStockUpdatedEvent:
+		return "ProductStockUpdatedEvent", &eventavro.ProductStockUpdatedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			OrderID:       e.OrderID,
+			UserID:        e.UserID,
+			Products:      productItemsToAvro(e.Products),
+			EventTime:     e.EventTime,
+		}, nil
+	case models.ProductStockRollbackEvent:
+		return "ProductStockRollbackEvent", &eventavro.ProductStockRollbackEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			OrderID:       e.OrderID,
+			UserID:        e.UserID,
+			Products:      productItemsToAvro(e.Products),
+			EventTime:     e.EventTime,
+			ReservationID: e.ReservationID,
+		}, nil
+	case models.StockReservationEvent:
+		return "StockReservationEvent", &eventavro.StockReservationEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			OrderID:       e.OrderID,
+			UserID:        e.UserID,
+			TotalAmount:   e.TotalAmount,
+			Products:      productItemsToAvro(e.Products),
+			Reason:        e.Reason,
+			EventTime:     e.EventTime,
+			ReservationID: e.ReservationID,
+			Shortfalls:    shortfallsToAvro(e.Shortfalls),
+		}, nil
+	case models.StockChangedEvent:
+		return "StockChangedEvent", &eventavro.StockChangedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			ProductID:     e.ProductID,
+			CategoryID:    int64(e.CategoryID),
+			OldStock:      int64(e.OldStock),
+			NewStock:      int64(e.NewStock),
+			Delta:         int64(e.Delta),
+			Reason:        e.Reason,
+			EventTime:     e.EventTime,
+		}, nil
+	case models.StockLevelEvent:
+		return "StockLevelEvent", &eventavro.StockLevelEvent{
+			SchemaVersion:     int64(e.SchemaVersion),
+			EventType:         e.EventType,
+			ProductID:         e.ProductID,
+			CategoryID:        int64(e.CategoryID),
+			Stock:             int64(e.Stock),
+			LowStockThreshold: int64(e.LowStockThreshold),
+			PreviousLevel:     e.PreviousLevel,
+			EventTime:         e.EventTime,
+		}, nil
+	case models.StockBackorderEvent:
+		return "StockBackorderEvent", &eventavro.StockBackorderEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			EventType:     e.EventType,
+			OrderID:       e.OrderID,
+			ReservationID: e.ReservationID,
+			Items:         backorderItemsToAvro(e.Items),
+			EventTime:     e.EventTime,
+		}, nil
+	case models.ProductChangedEvent:
+		return "ProductChangedEvent", &eventavro.ProductChangedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			EventType:     e.EventType,
+			ProductID:     e.ProductID,
+			Before:        productToAvro(e.Before),
+			After:         productToAvro(e.After),
+			EventTime:     e.EventTime,
+		}, nil
+	case models.CategoryChangedEvent:
+		return "CategoryChangedEvent", &eventavro.CategoryChangedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			EventType:     e.EventType,
+			CategoryID:    int64(e.CategoryID),
+			Before:        categoryToAvro(e.Before),
+			After:         categoryToAvro(e.After),
+			EventTime:     e.EventTime,
+		}, nil
+	}
+	return "", nil, fmt.Errorf("avro codec: unsupported event type %T", event)
+}
+
+func fromAvro(data []byte, event any) error {
+	switch e := event.(type) {
+	case *models.OrderCreatedEvent:
+		var r eventavro.OrderCreatedEvent
+		if err := unmarshalAvro("OrderCreatedEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.OrderCreatedEvent{
+			SchemaVersion:   int(r.SchemaVersion),
+			OrderID:         r.OrderID,
+			UserID:          r.UserID,
+			TotalAmount:     r.TotalAmount,
+			PaymentMethod:   r.PaymentMethod,
+			ShippingAddress: r.ShippingAddress,
+			Products:        productItemsFromAvro(r.Products),
+		}
+	case *models.ProductStockUpdatedEvent:
+		var r eventavro.ProductStockUpdatedEvent
+		if err := unmarshalAvro("ProductStockUpdatedEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.ProductStockUpdatedEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			OrderID:       r.OrderID,
+			UserID:        r.UserID,
+			Products:      productItemsFromAvro(r.Products),
+			EventTime:     r.EventTime,
+		}
+	case *models.ProductStockRollbackEvent:
+		var r eventavro.ProductStockRollbackEvent
+		if err := unmarshalAvro("ProductStockRollbackEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.ProductStockRollbackEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			OrderID:       r.OrderID,
+			UserID:        r.UserID,
+			Products:      productItemsFromAvro(r.Products),
+			EventTime:     r.EventTime,
+			ReservationID: r.ReservationID,
+		}
+	case *models.StockReservationEvent:
+		var r eventavro.StockReservationEvent
+		if err := unmarshalAvro("StockReservationEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.StockReservationEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			OrderID:       r.OrderID,
+			UserID:        r.UserID,
+			TotalAmount:   r.TotalAmount,
+			Products:      productItemsFromAvro(r.Products),
+			Reason:        r.Reason,
+			EventTime:     r.EventTime,
+			ReservationID: r.ReservationID,
+			Shortfalls:    shortfallsFromAvro(r.Shortfalls),
+		}
+	case *models.StockChangedEvent:
+		var r eventavro.StockChangedEvent
+		if err := unmarshalAvro("StockChangedEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.StockChangedEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			ProductID:     r.ProductID,
+			CategoryID:    int(r.CategoryID),
+			OldStock:      int(r.OldStock),
+			NewStock:      int(r.NewStock),
+			Delta:         int(r.Delta),
+			Reason:        r.Reason,
+			EventTime:     r.EventTime,
+		}
+	case *models.StockLevelEvent:
+		var r eventavro.StockLevelEvent
+		if err := unmarshalAvro("StockLevelEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.StockLevelEvent{
+			SchemaVersion:     int(r.SchemaVersion),
+			EventType:         r.EventType,
+			ProductID:         r.ProductID,
+			CategoryID:        int(r.CategoryID),
+			Stock:             int(r.Stock),
+			LowStockThreshold: int(r.LowStockThreshold),
+			PreviousLevel:     r.PreviousLevel,
+			EventTime:         r.EventTime,
+		}
+	case *models.StockBackorderEvent:
+		var r eventavro.StockBackorderEvent
+		if err := unmarshalAvro("StockBackorderEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.StockBackorderEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			EventType:     r.EventType,
+			OrderID:       r.OrderID,
+			ReservationID: r.ReservationID,
+			Items:         backorderItemsFromAvro(r.Items),
+			EventTime:     r.EventTime,
+		}
+	case *models.ProductChangedEvent:
+		var r eventavro.ProductChangedEvent
+		if err := unmarshalAvro("ProductChangedEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.ProductChangedEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			EventType:     r.EventType,
+			ProductID:     r.ProductID,
+			Before:        productFromAvro(r.Before),
+			After:         productFromAvro(r.After),
+			EventTime:     r.EventTime,
+		}
+	case *models.CategoryChangedEvent:
+		var r eventavro.CategoryChangedEvent
+		if err := unmarshalAvro("CategoryChangedEvent", data, &r); err != nil {
+			return err
+		}
+		*e = models.CategoryChangedEvent{
+			SchemaVersion: int(r.SchemaVersion),
+			EventType:     r.EventType,
+			CategoryID:    int(r.CategoryID),
+			Before:        categoryFromAvro(r.Before),
+			After:         categoryFromAvro(r.After),
+			EventTime:     r.EventTime,
+		}
+	default:
+		return fmt.Errorf("avro codec: unsupported event type %T", event)
+	}
+	return nil
+}
+
+func productItemsToAvro(items []models.ProductItem) []eventavro.ProductItem {
+	out := make([]eventavro.ProductItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, eventavro.ProductItem{ProductID: item.ProductID, Quantity: int64(item.Quantity), LineID: item.LineID})
+	}
+	return out
+}
+
+func productItemsFromAvro(items []eventavro.ProductItem) []models.ProductItem {
+	out := make([]models.ProductItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, models.ProductItem{ProductID: item.ProductID, Quantity: int(item.Quantity), LineID: item.LineID})
+	}
+	return out
+}
+
+func shortfallsToAvro(shortfalls []models.StockShortfall) []eventavro.StockShortfall {
+	out := make([]eventavro.StockShortfall, 0, len(shortfalls))
+	for _, s := range shortfalls {
+		out = append(out, eventavro.StockShortfall{ProductID: s.ProductID, Requested: int64(s.Requested), Available: int64(s.Available)})
+	}
+	return out
+}
+
+func shortfallsFromAvro(shortfalls []eventavro.StockShortfall) []models.StockShortfall {
+	var out []models.StockShortfall
+	for _, s := range shortfalls {
+		out = append(out, models.StockShortfall{ProductID: s.ProductID, Requested: int(s.Requested), Available: int(s.Available)})
+	}
+	return out
+}
+
+func backorderItemsToAvro(items []models.BackorderItem) []eventavro.BackorderItem {
+	out := make([]eventavro.BackorderItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, eventavro.BackorderItem{
+			ProductID:         item.ProductID,
+			LineID:            item.LineID,
+			Quantity:          int64(item.Quantity),
+			Remaining:         int64(item.Remaining),
+			Mode:              item.Mode,
+			ExpectedRestockAt: item.ExpectedRestockAt,
+		})
+	}
+	return out
+}
+
+func backorderItemsFromAvro(items []eventavro.BackorderItem) []models.BackorderItem {
+	out := make([]models.BackorderItem, 0, len(items))
+	for _, r := range items {
+		out = append(out, models.BackorderItem{
+			ProductID:         r.ProductID,
+			LineID:            r.LineID,
+			Quantity:          int(r.Quantity),
+			Remaining:         int(r.Remaining),
+			Mode:              r.Mode,
+			ExpectedRestockAt: r.ExpectedRestockAt,
+		})
+	}
+	return out
+}
+
+func productToAvro(p *models.Product) *eventavro.Product {
+	if p == nil {
+		return nil
+	}
+	return &eventavro.Product{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Stock:       int64(p.Stock),
+		CategoryID:  int64(p.CategoryID),
+		Category:    eventavro.ProductCategory{ID: int64(p.Category.ID), Name: p.Category.Name},
+	}
+}
+
+func productFromAvro(p *eventavro.Product) *models.Product {
+	if p == nil {
+		return nil
+	}
+	return &models.Product{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Stock:       int(p.Stock),
+		CategoryID:  int(p.CategoryID),
+		Category:    models.ProductCategory{ID: int(p.Category.ID), Name: p.Category.Name},
+	}
+}
+
+func categoryToAvro(c *models.ProductCategory) *eventavro.ProductCategory {
+	if c == nil {
+		return nil
+	}
+	return &eventavro.ProductCategory{ID: int64(c.ID), Name: c.Name}
+}
+
+func categoryFromAvro(c *eventavro.ProductCategory) *models.ProductCategory {
+	if c == nil {
+		return nil
+	}
+	return &models.ProductCategory{ID: int(c.ID), Name: c.Name}
+}
@@ -0,0 +1,157 @@
+package codec
+
+import (
+	"fmt"
+	"mime"
+
+	"productfc/config"
+	"productfc/kafka/schema"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// 발행 형식 이름(설정값)과 content-type 헤더 값.
+const (
+	NameJSON     = "json"
+	NameProtobuf = "protobuf"
+	NameAvro     = "avro"
+
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeAvro     = "application/avro"
+
+	// HeaderContentType — CloudEvents binary mode의 datacontenttype 헤더와 같은 키.
+	HeaderContentType = "content-type"
+)
+
+// Codec — 이벤트 직렬화 형식. subject는 schema 패키지의 이벤트 스키마 이름.
+// Marshal은 models 이벤트 값, Unmarshal은 models 이벤트 포인터를 받음.
+// 모든 형식이 같은 JSON Schema로 검증해 형식과 무관하게 같은 규칙을 적용.
+type Codec interface {
+	Name() string
+	ContentType() string
+	Marshal(subject string, event any) ([]byte, error)
+	Unmarshal(subject string, data []byte, event any) error
+}
+
+var (
+	JSON     Codec = jsonCodec{}
+	Protobuf Codec = protobufCodec{}
+	Avro     Codec = avroCodec{}
+)
+
+var codecs = []Codec{JSON, Protobuf, Avro}
+
+// contentTypeAliases — 다른 서비스가 쓰는 동의어.
+var contentTypeAliases = map[string]Codec{
+	"application/protobuf":               Protobuf,
+	"application/vnd.apache.avro+binary": Avro,
+}
+
+// ForName — 설정 이름(json | protobuf | avro)으로 codec 선택.
+func ForName(name string) (Codec, error) {
+	for _, c := range codecs {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported kafka codec %q (json | protobuf | avro)", name)
+}
+
+// ForContentType — 수신 content-type으로 디코더 선택. 비어 있으면 JSON (레거시 메시지).
+func ForContentType(contentType string) (Codec, error) {
+	if contentType == "" {
+		return JSON, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content-type %q: %w", contentType, err)
+	}
+	for _, c := range codecs {
+		if c.ContentType() == mediaType {
+			return c, nil
+		}
+	}
+	if c, ok := contentTypeAliases[mediaType]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("unsupported content-type %q", contentType)
+}
+
+// ContentType — 메시지의 content-type 헤더 (없으면 "").
+func ContentType(msg kafka.Message) string {
+	for _, h := range msg.Headers {
+		if h.Key == HeaderContentType {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// WithContentType — content-type 헤더를 교체한 헤더 복사본 (contentType이 비면 제거).
+func WithContentType(headers []kafka.Header, contentType string) []kafka.Header {
+	out := make([]kafka.Header, 0, len(headers)+1)
+	for _, h := range headers {
+		if h.Key != HeaderContentType {
+			out = append(out, h)
+		}
+	}
+	if contentType != "" {
+		out = append(out, kafka.Header{Key: HeaderContentType, Value: []byte(contentType)})
+	}
+	return out
+}
+
+// Selector — 토픽별 발행 codec. nil이면 모두 JSON.
+type Selector struct {
+	def    Codec
+	topics map[string]Codec
+}
+
+// NewSelector — topics는 설정 이름(stock_reserved 등) → 실제 토픽명. cfg.Topics는 같은 이름으로 재정의.
+func NewSelector(cfg config.KafkaCodecConfig, topics map[string]string) (*Selector, error) {
+	s := &Selector{def: JSON, topics: make(map[string]Codec, len(cfg.Topics))}
+	if cfg.Default != "" {
+		c, err := ForName(cfg.Default)
+		if err != nil {
+			return nil, err
+		}
+		s.def = c
+	}
+	for name, codecName := range cfg.Topics {
+		topic, ok := topics[name]
+		if !ok {
+			return nil, fmt.Errorf("codec: unknown topic name %q", name)
+		}
+		c, err := ForName(codecName)
+		if err != nil {
+			return nil, err
+		}
+		s.topics[topic] = c
+	}
+	return s, nil
+}
+
+func (s *Selector) For(topic string) Codec {
+	if s == nil {
+		return JSON
+	}
+	if c, ok := s.topics[topic]; ok {
+		return c
+	}
+	return s.def
+}
+
+// jsonCodec — 기존 JSON 본문. 스키마 버전 검증 + 업캐스트.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string        { return NameJSON }
+func (jsonCodec) ContentType() string { return ContentTypeJSON }
+
+func (jsonCodec) Marshal(subject string, event any) ([]byte, error) {
+	return schema.Default.Encode(subject, event)
+}
+
+func (jsonCodec) Unmarshal(subject string, data []byte, event any) error {
+	return schema.Default.Decode(subject, data, event)
+}
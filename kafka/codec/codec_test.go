@@ -0,0 +1,280 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"productfc/config"
+	"productfc/kafka/codec/eventavro"
+	"productfc/kafka/schema"
+	"productfc/models"
+
+	"github.com/hamba/avro/v2"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// subjectEvents — 스키마 이름별 models 이벤트 포인터.
+var subjectEvents = map[string]func() any{
+	schema.OrderCreated:     func() any { return &models.OrderCreatedEvent{} },
+	schema.StockUpdated:     func() any { return &models.ProductStockUpdatedEvent{} },
+	schema.StockRollback:    func() any { return &models.ProductStockRollbackEvent{} },
+	schema.StockReservation: func() any { return &models.StockReservationEvent{} },
+	schema.StockChanged:     func() any { return &models.StockChangedEvent{} },
+	schema.StockLevel:       func() any { return &models.StockLevelEvent{} },
+	schema.StockBackorder:   func() any { return &models.StockBackorderEvent{} },
+	schema.ProductChanged:   func() any { return &models.ProductChangedEvent{} },
+	schema.CategoryChanged:  func() any { return &models.CategoryChangedEvent{} },
+}
+
+// goldenEvent — schema 패키지의 현재 버전 golden sample을 models 이벤트로 읽음.
+func goldenEvent(t *testing.T, subject string, version int) any {
+	t.Helper()
+	newEvent, ok := subjectEvents[subject]
+	if !ok {
+		t.Fatalf("%s: no event type registered in test", subject)
+	}
+	data, err := os.ReadFile(filepath.Join("..", "schema", "samples", subject, fmt.Sprintf("v%d.json", version)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := newEvent()
+	if err := schema.Default.Decode(subject, data, event); err != nil {
+		t.Fatalf("%s: decode golden sample: %v", subject, err)
+	}
+	return event
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	for subject, version := range schema.Default.Subjects() {
+		for _, c := range codecs {
+			t.Run(subject+"/"+c.Name(), func(t *testing.T) {
+				want := goldenEvent(t, subject, version)
+				data, err := c.Marshal(subject, reflect.ValueOf(want).Elem().Interface())
+				if err != nil {
+					t.Fatalf("Marshal: %v", err)
+				}
+				got := subjectEvents[subject]()
+				if err := c.Unmarshal(subject, data, got); err != nil {
+					t.Fatalf("Unmarshal: %v", err)
+				}
+				wantJSON, _ := json.Marshal(want)
+				gotJSON, _ := json.Marshal(got)
+				if string(gotJSON) != string(wantJSON) {
+					t.Fatalf("round trip mismatch\n got: %s\nwant: %s", gotJSON, wantJSON)
+				}
+			})
+		}
+	}
+}
+
+// golden sample에 없는 선택 필드(reservation_id, shortfalls)까지 모든 형식에서 보존되는지 확인.
+func TestCodecRoundTripReservation(t *testing.T) {
+	want := models.StockReservationEvent{
+		SchemaVersion: 1,
+		OrderID:       1001,
+		UserID:        42,
+		TotalAmount:   35000,
+		Products:      []models.ProductItem{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}},
+		EventTime:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		ReservationID: "3f2b8c1e-7a4d-4e0b-9c5a-2d1e6f7a8b9c",
+		Shortfalls:    []models.StockShortfall{{ProductID: 2, Requested: 3, Available: 1}},
+	}
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			data, err := c.Marshal(schema.StockReservation, want)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var got models.StockReservationEvent
+			if err := c.Unmarshal(schema.StockReservation, data, &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !got.EventTime.Equal(want.EventTime) {
+				t.Fatalf("EventTime = %v, want %v", got.EventTime, want.EventTime)
+			}
+			got.EventTime = want.EventTime
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip mismatch\n got: %+v\nwant: %+v", got, want)
+			}
+		})
+	}
+}
+
+// 이전 버전(v1) 프로듀서가 보낸 binary 본문도 JSON처럼 현재 버전으로 업캐스트돼야 함.
+func TestCodecUpcastsOlderBinaryVersion(t *testing.T) {
+	v1 := models.StockChangedEvent{
+		SchemaVersion: 1,
+		ProductID:     1,
+		CategoryID:    3,
+		OldStock:      10,
+		NewStock:      8,
+		Delta:         -2,
+		Reason:        "reserved",
+		EventTime:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	encode := map[string]func(t *testing.T) []byte{
+		NameProtobuf: func(t *testing.T) []byte {
+			msg, err := toProto(v1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := proto.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		},
+		NameAvro: func(t *testing.T) []byte {
+			record, v, err := toAvro(v1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := eventavro.Schema(record)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := avro.Marshal(s, v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		},
+		NameJSON: func(t *testing.T) []byte {
+			b, err := json.Marshal(v1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		},
+	}
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			var got models.StockChangedEvent
+			if err := c.Unmarshal(schema.StockChanged, encode[c.Name()](t), &got); err != nil {
+				t.Fatalf("Unmarshal v1: %v", err)
+			}
+			want := v1
+			want.SchemaVersion = schema.Default.Current(schema.StockChanged)
+			if !got.EventTime.Equal(want.EventTime) {
+				t.Fatalf("EventTime = %v, want %v", got.EventTime, want.EventTime)
+			}
+			got.EventTime = want.EventTime
+			if got != want {
+				t.Fatalf("got %+v, want %+v", got, want)
+			}
+
+			// 업캐스트된 값은 현재 버전으로 다시 발행 가능해야 함
+			data, err := c.Marshal(schema.StockChanged, got)
+			if err != nil {
+				t.Fatalf("Marshal upcast event: %v", err)
+			}
+			var again models.StockChangedEvent
+			if err := c.Unmarshal(schema.StockChanged, data, &again); err != nil {
+				t.Fatalf("Unmarshal current: %v", err)
+			}
+			if again.SchemaVersion != want.SchemaVersion {
+				t.Fatalf("schema_version = %d, want %d", again.SchemaVersion, want.SchemaVersion)
+			}
+		})
+	}
+}
+
+func TestCodecRejectsInvalidEvent(t *testing.T) {
+	event := models.StockReservationEvent{SchemaVersion: 1, OrderID: 1, ReservationID: "not-a-uuid"}
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			if _, err := c.Marshal(schema.StockReservation, event); err == nil {
+				t.Fatal("Marshal error = nil, want schema validation error")
+			}
+		})
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        Codec
+		wantErr     bool
+	}{
+		{contentType: "", want: JSON},
+		{contentType: ContentTypeJSON, want: JSON},
+		{contentType: "application/json; charset=utf-8", want: JSON},
+		{contentType: ContentTypeProtobuf, want: Protobuf},
+		{contentType: "application/protobuf", want: Protobuf},
+		{contentType: ContentTypeAvro, want: Avro},
+		{contentType: "application/vnd.apache.avro+binary", want: Avro},
+		{contentType: "text/plain", wantErr: true},
+		{contentType: "not a media type;;", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			got, err := ForContentType(tt.contentType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ForContentType error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ForContentType = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentTypeHeaderRoundTrip(t *testing.T) {
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			headers := []kafka.Header{
+				{Key: "x-trace", Value: []byte("abc")},
+				{Key: HeaderContentType, Value: []byte("text/plain")},
+			}
+			msg := kafka.Message{Headers: WithContentType(headers, c.ContentType())}
+			got, err := ForContentType(ContentType(msg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c {
+				t.Fatalf("codec from header = %s, want %s", got.Name(), c.Name())
+			}
+			if len(msg.Headers) != 2 {
+				t.Fatalf("headers = %v, want x-trace and one content-type", msg.Headers)
+			}
+		})
+	}
+}
+
+func TestSelector(t *testing.T) {
+	topics := map[string]string{"stock_reserved": "stock.reserved", "stock_changed": "stock.changed"}
+	s, err := NewSelector(config.KafkaCodecConfig{
+		Default: NameProtobuf,
+		Topics:  map[string]string{"stock_changed": NameAvro},
+	}, topics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.For("stock.reserved"); got != Protobuf {
+		t.Fatalf("For(default) = %s, want protobuf", got.Name())
+	}
+	if got := s.For("stock.changed"); got != Avro {
+		t.Fatalf("For(override) = %s, want avro", got.Name())
+	}
+	var nilSelector *Selector
+	if got := nilSelector.For("stock.reserved"); got != JSON {
+		t.Fatalf("nil selector For = %s, want json", got.Name())
+	}
+
+	invalid := []config.KafkaCodecConfig{
+		{Default: "xml"},
+		{Topics: map[string]string{"nope": NameJSON}},
+		{Topics: map[string]string{"stock_changed": "xml"}},
+	}
+	for _, cfg := range invalid {
+		if _, err := NewSelector(cfg, topics); err == nil {
+			t.Fatalf("NewSelector(%+v) error = nil, want error", cfg)
+		}
+	}
+}
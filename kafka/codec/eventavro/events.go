@@ -0,0 +1,144 @@
+// Code generated by avro/gen. DO NOT EDIT.
+package eventavro
+
+import (
+	"time"
+)
+
+// ProductItem is a generated struct.
+type ProductItem struct {
+	ProductID int64 `avro:"product_id" json:"product_id"`
+	Quantity  int64 `avro:"quantity" json:"quantity"`
+	LineID    int64 `avro:"line_id" json:"line_id"`
+}
+
+// ProductCategory is a generated struct.
+type ProductCategory struct {
+	ID   int64  `avro:"id" json:"id"`
+	Name string `avro:"name" json:"name"`
+}
+
+// Product is a generated struct.
+type Product struct {
+	ID          int64           `avro:"id" json:"id"`
+	Name        string          `avro:"name" json:"name"`
+	Description string          `avro:"description" json:"description"`
+	Price       float64         `avro:"price" json:"price"`
+	Stock       int64           `avro:"stock" json:"stock"`
+	CategoryID  int64           `avro:"category_id" json:"category_id"`
+	Category    ProductCategory `avro:"category" json:"category"`
+}
+
+// OrderCreatedEvent is a generated struct.
+type OrderCreatedEvent struct {
+	SchemaVersion   int64         `avro:"schema_version" json:"schema_version"`
+	OrderID         int64         `avro:"order_id" json:"order_id"`
+	UserID          int64         `avro:"user_id" json:"user_id"`
+	TotalAmount     float64       `avro:"total_amount" json:"total_amount"`
+	PaymentMethod   string        `avro:"payment_method" json:"payment_method"`
+	ShippingAddress string        `avro:"shipping_address" json:"shipping_address"`
+	Products        []ProductItem `avro:"products" json:"products"`
+}
+
+// ProductStockUpdatedEvent is a generated struct.
+type ProductStockUpdatedEvent struct {
+	SchemaVersion int64         `avro:"schema_version" json:"schema_version"`
+	OrderID       int64         `avro:"order_id" json:"order_id"`
+	UserID        int64         `avro:"user_id" json:"user_id"`
+	Products      []ProductItem `avro:"products" json:"products"`
+	EventTime     time.Time     `avro:"event_time" json:"event_time"`
+}
+
+// ProductStockRollbackEvent is a generated struct.
+type ProductStockRollbackEvent struct {
+	SchemaVersion int64         `avro:"schema_version" json:"schema_version"`
+	OrderID       int64         `avro:"order_id" json:"order_id"`
+	UserID        int64         `avro:"user_id" json:"user_id"`
+	Products      []ProductItem `avro:"products" json:"products"`
+	EventTime     time.Time     `avro:"event_time" json:"event_time"`
+	ReservationID string        `avro:"reservation_id" json:"reservation_id"`
+}
+
+// StockShortfall is a generated struct.
+type StockShortfall struct {
+	ProductID int64 `avro:"product_id" json:"product_id"`
+	Requested int64 `avro:"requested" json:"requested"`
+	Available int64 `avro:"available" json:"available"`
+}
+
+// StockReservationEvent is a generated struct.
+type StockReservationEvent struct {
+	SchemaVersion int64            `avro:"schema_version" json:"schema_version"`
+	OrderID       int64            `avro:"order_id" json:"order_id"`
+	UserID        int64            `avro:"user_id" json:"user_id"`
+	TotalAmount   float64          `avro:"total_amount" json:"total_amount"`
+	Products      []ProductItem    `avro:"products" json:"products"`
+	Reason        string           `avro:"reason" json:"reason"`
+	EventTime     time.Time        `avro:"event_time" json:"event_time"`
+	ReservationID string           `avro:"reservation_id" json:"reservation_id"`
+	Shortfalls    []StockShortfall `avro:"shortfalls" json:"shortfalls"`
+}
+
+// StockChangedEvent is a generated struct.
+type StockChangedEvent struct {
+	SchemaVersion int64     `avro:"schema_version" json:"schema_version"`
+	ProductID     int64     `avro:"product_id" json:"product_id"`
+	CategoryID    int64     `avro:"category_id" json:"category_id"`
+	OldStock      int64     `avro:"old_stock" json:"old_stock"`
+	NewStock      int64     `avro:"new_stock" json:"new_stock"`
+	Delta         int64     `avro:"delta" json:"delta"`
+	Reason        string    `avro:"reason" json:"reason"`
+	EventTime     time.Time `avro:"event_time" json:"event_time"`
+}
+
+// StockLevelEvent is a generated struct.
+type StockLevelEvent struct {
+	SchemaVersion     int64     `avro:"schema_version" json:"schema_version"`
+	EventType         string    `avro:"event_type" json:"event_type"`
+	ProductID         int64     `avro:"product_id" json:"product_id"`
+	CategoryID        int64     `avro:"category_id" json:"category_id"`
+	Stock             int64     `avro:"stock" json:"stock"`
+	LowStockThreshold int64     `avro:"low_stock_threshold" json:"low_stock_threshold"`
+	PreviousLevel     string    `avro:"previous_level" json:"previous_level"`
+	EventTime         time.Time `avro:"event_time" json:"event_time"`
+}
+
+// BackorderItem is a generated struct.
+type BackorderItem struct {
+	ProductID         int64      `avro:"product_id" json:"product_id"`
+	LineID            int64      `avro:"line_id" json:"line_id"`
+	Quantity          int64      `avro:"quantity" json:"quantity"`
+	Remaining         int64      `avro:"remaining" json:"remaining"`
+	Mode              string     `avro:"mode" json:"mode"`
+	ExpectedRestockAt *time.Time `avro:"expected_restock_at" json:"expected_restock_at"`
+}
+
+// StockBackorderEvent is a generated struct.
+type StockBackorderEvent struct {
+	SchemaVersion int64           `avro:"schema_version" json:"schema_version"`
+	EventType     string          `avro:"event_type" json:"event_type"`
+	OrderID       int64           `avro:"order_id" json:"order_id"`
+	ReservationID string          `avro:"reservation_id" json:"reservation_id"`
+	Items         []BackorderItem `avro:"items" json:"items"`
+	EventTime     time.Time       `avro:"event_time" json:"event_time"`
+}
+
+// ProductChangedEvent is a generated struct.
+type ProductChangedEvent struct {
+	SchemaVersion int64     `avro:"schema_version" json:"schema_version"`
+	EventType     string    `avro:"event_type" json:"event_type"`
+	ProductID     int64     `avro:"product_id" json:"product_id"`
+	Before        *Product  `avro:"before" json:"before"`
+	After         *Product  `avro:"after" json:"after"`
+	EventTime     time.Time `avro:"event_time" json:"event_time"`
+}
+
+// CategoryChangedEvent is a generated struct.
+type CategoryChangedEvent struct {
+	SchemaVersion int64            `avro:"schema_version" json:"schema_version"`
+	EventType     string           `avro:"event_type" json:"event_type"`
+	CategoryID    int64            `avro:"category_id" json:"category_id"`
+	Before        *ProductCategory `avro:"before" json:"before"`
+	After         *ProductCategory `avro:"after" json:"after"`
+	EventTime     time.Time        `avro:"event_time" json:"event_time"`
+}
@@ -0,0 +1,61 @@
+// Package eventavro — *.avsc에서 생성한 Avro 이벤트 타입과 스키마.
+package eventavro
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+)
+
+//go:generate go run github.com/hamba/avro/v2/cmd/avrogen -pkg eventavro -o events.go -tags json:snake product_item.avsc product_category.avsc product.avsc order_created.avsc stock_updated.avsc stock_rollback.avsc stock_reservation.avsc stock_changed.avsc stock_level.avsc stock_backorder.avsc product_changed.avsc category_changed.avsc
+
+//go:embed *.avsc
+var schemaFS embed.FS
+
+// schemaFiles — 참조하는 레코드가 먼저 오도록 정렬 (ProductItem/ProductCategory/Product는 공통).
+var schemaFiles = []string{
+	"product_item.avsc",
+	"product_category.avsc",
+	"product.avsc",
+	"order_created.avsc",
+	"stock_updated.avsc",
+	"stock_rollback.avsc",
+	"stock_reservation.avsc",
+	"stock_changed.avsc",
+	"stock_level.avsc",
+	"stock_backorder.avsc",
+	"product_changed.avsc",
+	"category_changed.avsc",
+}
+
+// Namespace — 모든 레코드의 Avro namespace.
+const Namespace = "productfc.events.v1"
+
+var schemas = mustParse()
+
+func mustParse() map[string]avro.Schema {
+	cache := &avro.SchemaCache{}
+	out := make(map[string]avro.Schema, len(schemaFiles))
+	for _, name := range schemaFiles {
+		b, err := schemaFS.ReadFile(name)
+		if err != nil {
+			panic(err)
+		}
+		schema, err := avro.ParseBytesWithCache(b, "", cache)
+		if err != nil {
+			panic(fmt.Errorf("avro schema %s: %w", name, err))
+		}
+		out[schema.(avro.NamedSchema).Name()] = schema
+	}
+	return out
+}
+
+// Schema — 레코드 이름(예: StockReservationEvent)의 스키마.
+func Schema(record string) (avro.Schema, error) {
+	schema, ok := schemas[record]
+	if !ok {
+		return nil, fmt.Errorf("unknown avro record %q", record)
+	}
+	return schema, nil
+}
@@ -0,0 +1,4 @@
+// Package eventpb — events.proto에서 생성한 Protobuf 이벤트 타입.
+package eventpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative events.proto
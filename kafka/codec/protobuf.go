@@ -0,0 +1,388 @@
+package codec
+
+import (
+	"fmt"
+	"time"
+
+	"productfc/kafka/codec/eventpb"
+	"productfc/kafka/schema"
+	"productfc/models"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protobufCodec — eventpb(events.proto) 메시지로 직렬화.
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string        { return NameProtobuf }
+func (protobufCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (protobufCodec) Marshal(subject string, event any) ([]byte, error) {
+	if err := schema.Default.Validate(subject, event); err != nil {
+		return nil, err
+	}
+	msg, err := toProto(event)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(subject string, data []byte, event any) error {
+	if err := fromProto(data, event); err != nil {
+		return err
+	}
+	// 이전 버전 프로듀서가 보낸 값도 JSON과 같이 버전별 검증 + 업캐스트
+	return schema.Default.Upcast(subject, event)
+}
+
+func toProto(event any) (proto.Message, error) {
+	switch e := event.(type) {
+	case models.OrderCreatedEvent:
+		return &eventpb.OrderCreatedEvent{
+			SchemaVersion:   int64(e.SchemaVersion),
+			OrderId:         e.OrderID,
+			UserId:          e.UserID,
+			TotalAmount:     e.TotalAmount,
+			PaymentMethod:   e.PaymentMethod,
+			ShippingAddress: e.ShippingAddress,
+			Products:        productItemsToProto(e.Products),
+		}, nil
+	case models.ProductStockUpdatedEvent:
+		return &eventpb.ProductStockUpdatedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			OrderId:       e.OrderID,
+			UserId:        e.UserID,
+			Products:      productItemsToProto(e.Products),
+			EventTime:     timestamppb.New(e.EventTime),
+		}, nil
+	case models.ProductStockRollbackEvent:
+		return &eventpb.ProductStockRollbackEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			OrderId:       e.OrderID,
+			UserId:        e.UserID,
+			Products:      productItemsToProto(e.Products),
+			EventTime:     timestamppb.New(e.EventTime),
+			ReservationId: e.ReservationID,
+		}, nil
+	case models.StockReservationEvent:
+		return &eventpb.StockReservationEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			OrderId:       e.OrderID,
+			UserId:        e.UserID,
+			TotalAmount:   e.TotalAmount,
+			Products:      productItemsToProto(e.Products),
+			Reason:        e.Reason,
+			EventTime:     timestamppb.New(e.EventTime),
+			ReservationId: e.ReservationID,
+			Shortfalls:    shortfallsToProto(e.Shortfalls),
+		}, nil
+	case models.StockChangedEvent:
+		return &eventpb.StockChangedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			ProductId:     e.ProductID,
+			CategoryId:    int64(e.CategoryID),
+			OldStock:      int64(e.OldStock),
+			NewStock:      int64(e.NewStock),
+			Delta:         int64(e.Delta),
+			Reason:        e.Reason,
+			EventTime:     timestamppb.New(e.EventTime),
+		}, nil
+	case models.StockLevelEvent:
+		return &eventpb.StockLevelEvent{
+			SchemaVersion:     int64(e.SchemaVersion),
+			EventType:         e.EventType,
+			ProductId:         e.ProductID,
+			CategoryId:        int64(e.CategoryID),
+			Stock:             int64(e.Stock),
+			LowStockThreshold: int64(e.LowStockThreshold),
+			PreviousLevel:     e.PreviousLevel,
+			EventTime:         timestamppb.New(e.EventTime),
+		}, nil
+	case models.StockBackorderEvent:
+		return &eventpb.StockBackorderEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			EventType:     e.EventType,
+			OrderId:       e.OrderID,
+			ReservationId: e.ReservationID,
+			Items:         backorderItemsToProto(e.Items),
+			EventTime:     timestamppb.New(e.EventTime),
+		}, nil
+	case models.ProductChangedEvent:
+		return &eventpb.ProductChangedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			EventType:     e.EventType,
+			ProductId:     e.ProductID,
+			Before:        productToProto(e.Before),
+			After:         productToProto(e.After),
+			EventTime:     timestamppb.New(e.EventTime),
+		}, nil
+	case models.CategoryChangedEvent:
+		return &eventpb.CategoryChangedEvent{
+			SchemaVersion: int64(e.SchemaVersion),
+			EventType:     e.EventType,
+			CategoryId:    int64(e.CategoryID),
+			Before:        categoryToProto(e.Before),
+			After:         categoryToProto(e.After),
+			EventTime:     timestamppb.New(e.EventTime),
+		}, nil
+	}
+	return nil, fmt.Errorf("protobuf codec: unsupported event type %T", event)
+}
+
+func fromProto(data []byte, event any) error {
+	switch e := event.(type) {
+	case *models.OrderCreatedEvent:
+		var msg eventpb.OrderCreatedEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.OrderCreatedEvent{
+			SchemaVersion:   int(msg.SchemaVersion),
+			OrderID:         msg.OrderId,
+			UserID:          msg.UserId,
+			TotalAmount:     msg.TotalAmount,
+			PaymentMethod:   msg.PaymentMethod,
+			ShippingAddress: msg.ShippingAddress,
+			Products:        productItemsFromProto(msg.Products),
+		}
+	case *models.ProductStockUpdatedEvent:
+		var msg eventpb.ProductStockUpdatedEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.ProductStockUpdatedEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			OrderID:       msg.OrderId,
+			UserID:        msg.UserId,
+			Products:      productItemsFromProto(msg.Products),
+			EventTime:     timeFromProto(msg.EventTime),
+		}
+	case *models.ProductStockRollbackEvent:
+		var msg eventpb.ProductStockRollbackEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.ProductStockRollbackEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			OrderID:       msg.OrderId,
+			UserID:        msg.UserId,
+			Products:      productItemsFromProto(msg.Products),
+			EventTime:     timeFromProto(msg.EventTime),
+			ReservationID: msg.ReservationId,
+		}
+	case *models.StockReservationEvent:
+		var msg eventpb.StockReservationEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.StockReservationEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			OrderID:       msg.OrderId,
+			UserID:        msg.UserId,
+			TotalAmount:   msg.TotalAmount,
+			Products:      productItemsFromProto(msg.Products),
+			Reason:        msg.Reason,
+			EventTime:     timeFromProto(msg.EventTime),
+			ReservationID: msg.ReservationId,
+			Shortfalls:    shortfallsFromProto(msg.Shortfalls),
+		}
+	case *models.StockChangedEvent:
+		var msg eventpb.StockChangedEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.StockChangedEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			ProductID:     msg.ProductId,
+			CategoryID:    int(msg.CategoryId),
+			OldStock:      int(msg.OldStock),
+			NewStock:      int(msg.NewStock),
+			Delta:         int(msg.Delta),
+			Reason:        msg.Reason,
+			EventTime:     timeFromProto(msg.EventTime),
+		}
+	case *models.StockLevelEvent:
+		var msg eventpb.StockLevelEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.StockLevelEvent{
+			SchemaVersion:     int(msg.SchemaVersion),
+			EventType:         msg.EventType,
+			ProductID:         msg.ProductId,
+			CategoryID:        int(msg.CategoryId),
+			Stock:             int(msg.Stock),
+			LowStockThreshold: int(msg.LowStockThreshold),
+			PreviousLevel:     msg.PreviousLevel,
+			EventTime:         timeFromProto(msg.EventTime),
+		}
+	case *models.StockBackorderEvent:
+		var msg eventpb.StockBackorderEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.StockBackorderEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			EventType:     msg.EventType,
+			OrderID:       msg.OrderId,
+			ReservationID: msg.ReservationId,
+			Items:         backorderItemsFromProto(msg.Items),
+			EventTime:     timeFromProto(msg.EventTime),
+		}
+	case *models.ProductChangedEvent:
+		var msg eventpb.ProductChangedEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.ProductChangedEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			EventType:     msg.EventType,
+			ProductID:     msg.ProductId,
+			Before:        productFromProto(msg.Before),
+			After:         productFromProto(msg.After),
+			EventTime:     timeFromProto(msg.EventTime),
+		}
+	case *models.CategoryChangedEvent:
+		var msg eventpb.CategoryChangedEvent
+		if err := proto.Unmarshal(data, &msg); err != nil {
+			return err
+		}
+		*e = models.CategoryChangedEvent{
+			SchemaVersion: int(msg.SchemaVersion),
+			EventType:     msg.EventType,
+			CategoryID:    int(msg.CategoryId),
+			Before:        categoryFromProto(msg.Before),
+			After:         categoryFromProto(msg.After),
+			EventTime:     timeFromProto(msg.EventTime),
+		}
+	default:
+		return fmt.Errorf("protobuf codec: unsupported event type %T", event)
+	}
+	return nil
+}
+
+func productItemsToProto(items []models.ProductItem) []*eventpb.ProductItem {
+	out := make([]*eventpb.ProductItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, &eventpb.ProductItem{ProductId: item.ProductID, Quantity: int64(item.Quantity), LineId: item.LineID})
+	}
+	return out
+}
+
+func productItemsFromProto(items []*eventpb.ProductItem) []models.ProductItem {
+	out := make([]models.ProductItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, models.ProductItem{ProductID: item.ProductId, Quantity: int(item.Quantity), LineID: item.LineId})
+	}
+	return out
+}
+
+func shortfallsToProto(shortfalls []models.StockShortfall) []*eventpb.StockShortfall {
+	out := make([]*eventpb.StockShortfall, 0, len(shortfalls))
+	for _, s := range shortfalls {
+		out = append(out, &eventpb.StockShortfall{ProductId: s.ProductID, Requested: int64(s.Requested), Available: int64(s.Available)})
+	}
+	return out
+}
+
+// shortfallsFromProto — 없으면 nil (JSON omitempty와 같은 결과).
+func shortfallsFromProto(shortfalls []*eventpb.StockShortfall) []models.StockShortfall {
+	var out []models.StockShortfall
+	for _, s := range shortfalls {
+		out = append(out, models.StockShortfall{ProductID: s.ProductId, Requested: int(s.Requested), Available: int(s.Available)})
+	}
+	return out
+}
+
+func backorderItemsToProto(items []models.BackorderItem) []*eventpb.BackorderItem {
+	out := make([]*eventpb.BackorderItem, 0, len(items))
+	for _, item := range items {
+		msg := &eventpb.BackorderItem{
+			ProductId: item.ProductID,
+			LineId:    item.LineID,
+			Quantity:  int64(item.Quantity),
+			Remaining: int64(item.Remaining),
+			Mode:      item.Mode,
+		}
+		if item.ExpectedRestockAt != nil {
+			msg.ExpectedRestockAt = timestamppb.New(*item.ExpectedRestockAt)
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+func backorderItemsFromProto(items []*eventpb.BackorderItem) []models.BackorderItem {
+	out := make([]models.BackorderItem, 0, len(items))
+	for _, msg := range items {
+		item := models.BackorderItem{
+			ProductID: msg.ProductId,
+			LineID:    msg.LineId,
+			Quantity:  int(msg.Quantity),
+			Remaining: int(msg.Remaining),
+			Mode:      msg.Mode,
+		}
+		if msg.ExpectedRestockAt != nil {
+			t := msg.ExpectedRestockAt.AsTime()
+			item.ExpectedRestockAt = &t
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func productToProto(p *models.Product) *eventpb.Product {
+	if p == nil {
+		return nil
+	}
+	return &eventpb.Product{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Stock:       int64(p.Stock),
+		CategoryId:  int64(p.CategoryID),
+		Category:    categoryToProto(&p.Category),
+	}
+}
+
+func productFromProto(p *eventpb.Product) *models.Product {
+	if p == nil {
+		return nil
+	}
+	product := &models.Product{
+		ID:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Stock:       int(p.Stock),
+		CategoryID:  int(p.CategoryId),
+	}
+	if c := categoryFromProto(p.Category); c != nil {
+		product.Category = *c
+	}
+	return product
+}
+
+func categoryToProto(c *models.ProductCategory) *eventpb.ProductCategory {
+	if c == nil {
+		return nil
+	}
+	return &eventpb.ProductCategory{Id: int64(c.ID), Name: c.Name}
+}
+
+func categoryFromProto(c *eventpb.ProductCategory) *models.ProductCategory {
+	if c == nil {
+		return nil
+	}
+	return &models.ProductCategory{ID: int(c.Id), Name: c.Name}
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
@@ -0,0 +1,205 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"productfc/config"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+var DefaultBrokers = []string{"kafka:9092"}
+
+const DefaultClientID = "productfc"
+
+// Connection — 브로커 주소와 인증(SASL/TLS) 설정. reader/writer 생성 시 공통으로 사용.
+type Connection struct {
+	Brokers   []string
+	Dialer    *kafka.Dialer
+	Transport *kafka.Transport
+}
+
+func NewConnection(cfg config.KafkaConfig) (*Connection, error) {
+	brokers := cfg.Brokers
+	if len(brokers) == 0 {
+		brokers = DefaultBrokers
+	}
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = DefaultClientID
+	}
+
+	mechanism, err := saslMechanism(cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := tlsConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{
+		Brokers: brokers,
+		Dialer: &kafka.Dialer{
+			ClientID:      clientID,
+			Timeout:       10 * time.Second,
+			DualStack:     true,
+			SASLMechanism: mechanism,
+			TLS:           tlsConfig,
+		},
+		Transport: &kafka.Transport{
+			ClientID: clientID,
+			SASL:     mechanism,
+			TLS:      tlsConfig,
+		},
+	}, nil
+}
+
+// Writer — 공통 인증 설정을 쓰는 writer. topic이 비어 있으면 메시지마다 토픽 지정.
+func (c *Connection) Writer(topic string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:      kafka.TCP(c.Brokers...),
+		Topic:     topic,
+		Balancer:  &kafka.Hash{},
+		Transport: c.Transport,
+	}
+}
+
+func saslMechanism(cfg config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	switch strings.ToLower(cfg.Mechanism) {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	default:
+		return nil, fmt.Errorf("unsupported kafka sasl mechanism %q", cfg.Mechanism)
+	}
+}
+
+func tlsConfig(cfg config.KafkaTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kafka CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in kafka CA file %s", cfg.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kafka client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+// Topics — 설정 재정의를 반영한 실제 토픽 이름.
+type Topics struct {
+	OrderCreated     string
+	StockReserved    string
+	StockRejected    string
+	StockUpdated     string
+	StockRollback    string
+	DLQOrderCreated  string
+	DLQStockUpdated  string
+	DLQStockRollback string
+	ProductCreated   string
+	ProductUpdated   string
+	ProductDeleted   string
+	CategoryCreated  string
+	CategoryUpdated  string
+	CategoryDeleted  string
+	ProductSnapshot  string
+	StockChanged     string
+	StockLow         string
+	StockOut         string
+	StockBackInStock string
+
+	StockBackordered        string
+	StockBackorderAllocated string
+}
+
+func ResolveTopics(cfg config.KafkaTopicsConfig) Topics {
+	return Topics{
+		OrderCreated:     orDefault(cfg.OrderCreated, TopicOrderCreated),
+		StockReserved:    orDefault(cfg.StockReserved, TopicStockReserved),
+		StockRejected:    orDefault(cfg.StockRejected, TopicStockRejected),
+		StockUpdated:     orDefault(cfg.StockUpdated, TopicStockUpdated),
+		StockRollback:    orDefault(cfg.StockRollback, TopicStockRollback),
+		DLQOrderCreated:  orDefault(cfg.DLQOrderCreated, TopicDLQOrderCreated),
+		DLQStockUpdated:  orDefault(cfg.DLQStockUpdated, TopicDLQStockUpdated),
+		DLQStockRollback: orDefault(cfg.DLQStockRollback, TopicDLQStockRollback),
+		ProductCreated:   orDefault(cfg.ProductCreated, TopicProductCreated),
+		ProductUpdated:   orDefault(cfg.ProductUpdated, TopicProductUpdated),
+		ProductDeleted:   orDefault(cfg.ProductDeleted, TopicProductDeleted),
+		CategoryCreated:  orDefault(cfg.CategoryCreated, TopicCategoryCreated),
+		CategoryUpdated:  orDefault(cfg.CategoryUpdated, TopicCategoryUpdated),
+		CategoryDeleted:  orDefault(cfg.CategoryDeleted, TopicCategoryDeleted),
+		ProductSnapshot:  orDefault(cfg.ProductSnapshot, TopicProductSnapshot),
+		StockChanged:     orDefault(cfg.StockChanged, TopicStockChanged),
+		StockLow:         orDefault(cfg.StockLow, TopicStockLow),
+		StockOut:         orDefault(cfg.StockOut, TopicStockOut),
+		StockBackInStock: orDefault(cfg.StockBackInStock, TopicStockBackInStock),
+
+		StockBackordered:        orDefault(cfg.StockBackordered, TopicStockBackordered),
+		StockBackorderAllocated: orDefault(cfg.StockBackorderAllocated, TopicStockBackorderAllocated),
+	}
+}
+
+// Named — 설정 이름(kafka.topics 키) → 실제 토픽명.
+func (t Topics) Named() map[string]string {
+	return map[string]string{
+		"order_created":       t.OrderCreated,
+		"stock_reserved":      t.StockReserved,
+		"stock_rejected":      t.StockRejected,
+		"stock_updated":       t.StockUpdated,
+		"stock_rollback":      t.StockRollback,
+		"dlq_order_created":   t.DLQOrderCreated,
+		"dlq_stock_updated":   t.DLQStockUpdated,
+		"dlq_stock_rollback":  t.DLQStockRollback,
+		"product_created":     t.ProductCreated,
+		"product_updated":     t.ProductUpdated,
+		"product_deleted":     t.ProductDeleted,
+		"category_created":    t.CategoryCreated,
+		"category_updated":    t.CategoryUpdated,
+		"category_deleted":    t.CategoryDeleted,
+		"product_snapshot":    t.ProductSnapshot,
+		"stock_changed":       t.StockChanged,
+		"stock_low":           t.StockLow,
+		"stock_out":           t.StockOut,
+		"stock_back_in_stock": t.StockBackInStock,
+
+		"stock_backordered":         t.StockBackordered,
+		"stock_backorder_allocated": t.StockBackorderAllocated,
+	}
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
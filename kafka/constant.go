@@ -10,5 +10,28 @@ const (
 	TopicDLQStockUpdated  = "stock.updated.dlq"
 	TopicDLQStockRollback = "stock.rollback.dlq"
 
-	SchemaVersionStockEvent = 1
+	TopicProductCreated   = "product.created"
+	TopicProductUpdated   = "product.updated"
+	TopicProductDeleted   = "product.deleted"
+	TopicCategoryCreated  = "category.created"
+	TopicCategoryUpdated  = "category.updated"
+	TopicCategoryDeleted  = "category.deleted"
+	TopicStockChanged     = "stock.changed"
+	TopicStockLow         = "stock.low"
+	TopicStockOut         = "stock.out"
+	TopicStockBackInStock = "stock.back_in_stock"
+
+	TopicStockBackordered        = "stock.backordered"
+	TopicStockBackorderAllocated = "stock.backorder_allocated"
+
+	// TopicProductSnapshot — 상품 ID 키별 최신 상태 (compacted, 삭제는 tombstone)
+	TopicProductSnapshot = "product.snapshot"
+
+	// HeaderEventID — CloudEvents를 끈(mode none) 토픽의 이벤트 ID (컨슈머 멱등성 키)
+	HeaderEventID = "x-event-id"
+
+	SchemaVersionStockEvent   = 1
+	SchemaVersionCatalogEvent = 1
+	// SchemaVersionStockChanged — stock.changed는 v2 (reason adjusted/stocktake/received)
+	SchemaVersionStockChanged = 2
 )
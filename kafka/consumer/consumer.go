@@ -0,0 +1,512 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"productfc/config"
+	"productfc/infrastructure/kafkamonitor"
+	kafkapkg "productfc/kafka"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/codec"
+	"productfc/kafka/dlq"
+	"productfc/kafka/idempotency"
+	"productfc/kafka/retry"
+	"productfc/kafka/schema"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Outcome — 메시지 한 건의 최종 처리 결과 (모니터/로그/트레이스 라벨).
+type Outcome string
+
+const (
+	OutcomeOK          Outcome = "ok"
+	OutcomeDuplicate   Outcome = "duplicate"
+	OutcomeRejected    Outcome = "rejected"
+	OutcomeRetried     Outcome = "retried"
+	OutcomeDLQ         Outcome = "dlq"
+	OutcomeDecodeError Outcome = "decode_error"
+)
+
+// ErrRejected — 핸들러가 비즈니스 규칙상 거절하고 처리를 마친 경우 (재시도/DLQ 없이 커밋).
+var ErrRejected = errors.New("message rejected")
+
+// incompleteError — 처리 미완료. 재시도 정책/DLQ를 거치지 않고 Runner가 커밋 없이 다시 처리.
+type incompleteError struct{ err error }
+
+func (e incompleteError) Error() string { return e.err.Error() }
+func (e incompleteError) Unwrap() error { return e.err }
+
+// Incomplete — 핸들러가 부분적으로만 처리했고 같은 메시지를 그대로 다시 받아야 할 때 반환
+// (예: 재고 변경 후 결과 이벤트 발행 실패).
+func Incomplete(err error) error {
+	return incompleteError{err: err}
+}
+
+// errRetryInterrupted — 프로세스 내 재시도 대기 중 종료 신호로 중단됨.
+var errRetryInterrupted = errors.New("in-process retry interrupted by shutdown")
+
+// Decoder — 메시지 본문을 이벤트 타입으로 변환.
+type Decoder[T any] func(msg kafka.Message) (T, error)
+
+// JSONDecoder — 기본 디코더.
+func JSONDecoder[T any](msg kafka.Message) (T, error) {
+	var event T
+	err := json.Unmarshal(msg.Value, &event)
+	return event, err
+}
+
+// SchemaDecoder — content-type 헤더로 codec(json/protobuf/avro)을 골라 디코드하고 스키마로 검증하는 디코더
+// (JSON은 현재 버전까지 업캐스트). 스키마 위반/미지원 버전은 *schema.Error (DLQ schema 분류).
+func SchemaDecoder[T any](subject string) Decoder[T] {
+	return func(msg kafka.Message) (T, error) {
+		var event T
+		c, err := codec.ForContentType(codec.ContentType(msg))
+		if err != nil {
+			return event, err
+		}
+		err = c.Unmarshal(subject, msg.Value, &event)
+		return event, err
+	}
+}
+
+// Handler — 이벤트 비즈니스 처리. nil이면 성공, ErrRejected면 거절 완료,
+// Incomplete(err)면 처리 미완료, 그 외 에러는 재시도 정책 후 DLQ.
+type Handler[T any] func(ctx context.Context, msg kafka.Message, event T) error
+
+// Result — 처리 결과와 그 원인 (디코드/검증/핸들러 에러, 성공이면 nil).
+type Result struct {
+	Outcome Outcome
+	Cause   error
+}
+
+// ProcessFunc — 미들웨어 체인 단위. 에러는 처리 미완료(커밋 안 함)를 뜻함.
+type ProcessFunc func(ctx context.Context, msg kafka.Message) (Result, error)
+
+// Middleware — 트레이싱/메트릭/로깅 등 처리 전후 훅.
+type Middleware func(next ProcessFunc) ProcessFunc
+
+// RetryPolicy — 핸들러 실패 시 프로세스 내 재시도 (소진되면 재시도 토픽, 마지막 tier 후 DLQ).
+type RetryPolicy struct {
+	MaxAttempts int
+	Delay       func(attempt int) time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Delay: func(attempt int) time.Duration {
+		return time.Duration(50*attempt) * time.Millisecond
+	},
+}
+
+// Config — 설정 파일에서 오는 연결/토픽/그룹 값 (ConfigFor로 생성).
+// Concurrency는 같은 그룹으로 띄울 reader 수 (파티션을 나눠 가짐),
+// Workers는 reader마다 키별 순서를 지키며 동시 처리할 워커 수 (재시도 tier 토픽은 항상 순차).
+type Config struct {
+	Conn           *kafkapkg.Connection
+	Topic          string
+	GroupID        string
+	Concurrency    int
+	Workers        int
+	MaxInFlight    int
+	MinBytes       int
+	MaxBytes       int
+	CommitInterval time.Duration
+	RetryTiers     []time.Duration
+	// RequireCloudEvents — CloudEvents가 아닌 레거시 본문은 schema 오류로 DLQ.
+	RequireCloudEvents bool
+	// IdempotencyLease — 이벤트 처리 점유 시간 (핸들러 최대 처리 시간보다 길어야 함).
+	IdempotencyLease time.Duration
+}
+
+// ConfigFor — kafka.consumers.<name> 설정을 공통값 위에 덮어써서 Config 생성.
+// group_id가 없으면 각 컨슈머 생성자의 기본 그룹 사용.
+func ConfigFor(cfg config.KafkaConfig, conn *kafkapkg.Connection, name, topic string) Config {
+	consumerCfg := cfg.Consumers[name]
+	c := Config{
+		Conn:           conn,
+		Topic:          topic,
+		GroupID:        consumerCfg.GroupID,
+		Concurrency:    consumerCfg.Concurrency,
+		Workers:        consumerCfg.Workers,
+		MaxInFlight:    consumerCfg.MaxInFlight,
+		MinBytes:       consumerCfg.MinBytes,
+		MaxBytes:       consumerCfg.MaxBytes,
+		CommitInterval: cfg.CommitInterval,
+		RetryTiers:     cfg.RetryTiers,
+
+		RequireCloudEvents: consumerCfg.RequireCloudEvents,
+		IdempotencyLease:   cfg.Idempotency.Lease,
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = cfg.Concurrency
+	}
+	if c.Workers <= 0 {
+		c.Workers = cfg.Workers
+	}
+	if c.MaxInFlight <= 0 {
+		c.MaxInFlight = cfg.MaxInFlight
+	}
+	if c.MinBytes <= 0 {
+		c.MinBytes = cfg.MinBytes
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = cfg.MaxBytes
+	}
+	if c.IdempotencyLease <= 0 {
+		c.IdempotencyLease = idempotency.DefaultLease
+	}
+	return c
+}
+
+func (c Config) withDefaultGroup(groupID string) Config {
+	if c.GroupID == "" {
+		c.GroupID = groupID
+	}
+	return c
+}
+
+// Options — Consumer[T] 구성. Handle 외에는 모두 선택 (기본: JSON 디코더, DefaultRetryPolicy,
+// 로깅/메트릭/트레이싱 미들웨어). RetryTiers가 있으면 tier별 재시도 토픽(<topic>.retry.<delay>)을
+// 함께 소비하고, 프로세스 내 재시도가 소진된 메시지는 다음 tier로 지연 재발행.
+type Options[T any] struct {
+	Config
+
+	Decode     Decoder[T]
+	Validate   func(event T) error
+	Attributes func(event T) []attribute.KeyValue
+	Handle     Handler[T]
+	// Duplicate — 이미 완료된 이벤트를 다시 받았을 때 호출 (nil이면 그냥 건너뜀). 상태 변경과 완료 기록은
+	// 같은 트랜잭션으로 커밋됐지만 결과 이벤트 발행은 그 뒤라 재시작으로 잃었을 수 있는 핸들러가 재발행에 사용.
+	// 에러면 커밋하지 않고 다시 받음.
+	Duplicate  Handler[T]
+	Retry      RetryPolicy
+	Middleware []Middleware
+
+	// Idempotency — 이벤트 ID(CloudEvents id, x-event-id 헤더, 없으면 원본 위치) 단위로 처리 점유.
+	// nil이면 멱등성 검사 없이 처리.
+	Idempotency idempotency.Store
+	DLQ         *dlq.Publisher
+	Monitor     *kafkamonitor.Monitor
+}
+
+// Consumer — 읽기 → 디코드 → 스키마 검사 → 멱등성 점유 → 재시도 → DLQ → 커밋 공통 파이프라인.
+type Consumer[T any] struct {
+	opts    Options[T]
+	retry   *retry.Publisher
+	runners []*Runner
+	process ProcessFunc
+
+	// held — Incomplete로 끝나 같은 메시지를 다시 받을 때까지 유지하는 claim (위치별)
+	mu   sync.Mutex
+	held map[string]idempotency.Claim
+}
+
+func New[T any](opts Options[T]) *Consumer[T] {
+	if opts.Decode == nil {
+		opts.Decode = JSONDecoder[T]
+	}
+	if opts.Retry.MaxAttempts <= 0 {
+		opts.Retry = DefaultRetryPolicy
+		if len(opts.RetryTiers) > 0 {
+			// 재시도 토픽이 있으면 파티션을 막는 프로세스 내 sleep 재시도는 생략
+			opts.Retry = RetryPolicy{MaxAttempts: 1}
+		}
+	}
+	if opts.Middleware == nil {
+		opts.Middleware = []Middleware{
+			TracingMiddleware(opts.Topic, opts.GroupID),
+			MetricsMiddleware(opts.Topic, opts.Monitor),
+			LoggingMiddleware(opts.Topic),
+		}
+	}
+
+	c := &Consumer[T]{opts: opts, held: make(map[string]idempotency.Claim)}
+
+	process := c.handle
+	for i := len(opts.Middleware) - 1; i >= 0; i-- {
+		process = opts.Middleware[i](process)
+	}
+	c.process = process
+
+	concurrency := max(opts.Concurrency, 1)
+	for i := 0; i < concurrency; i++ {
+		name := opts.Topic
+		if concurrency > 1 {
+			name = fmt.Sprintf("%s#%d", opts.Topic, i)
+		}
+		runner := c.newRunner(name, c.newReader(opts.Topic, opts.GroupID), nil)
+		runner.Workers = opts.Workers
+		runner.MaxInFlight = opts.MaxInFlight
+		c.runners = append(c.runners, runner)
+	}
+
+	if len(opts.RetryTiers) > 0 {
+		c.retry = retry.NewPublisher(opts.Conn, opts.Topic, opts.RetryTiers, opts.Monitor)
+		for _, tier := range c.retry.Tiers() {
+			reader := c.newReader(tier.Topic, opts.GroupID+"-retry-"+strings.TrimPrefix(tier.Topic, opts.Topic+".retry."))
+			c.runners = append(c.runners, c.newRunner(tier.Topic, reader, retry.Delay))
+		}
+	}
+	return c
+}
+
+func (c *Consumer[T]) newReader(topic, groupID string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        c.opts.Conn.Brokers,
+		Dialer:         c.opts.Conn.Dialer,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       c.opts.MinBytes,
+		MaxBytes:       c.opts.MaxBytes,
+		CommitInterval: c.opts.CommitInterval,
+	})
+}
+
+func (c *Consumer[T]) newRunner(name string, reader *kafka.Reader, delay func(kafka.Message) time.Duration) *Runner {
+	return &Runner{
+		Name:   name,
+		Reader: reader,
+		Handle: func(ctx context.Context, msg kafka.Message) error {
+			_, err := c.process(ctx, msg)
+			return err
+		},
+		Backoff: DefaultBackoff,
+		Monitor: c.opts.Monitor,
+		Delay:   delay,
+	}
+}
+
+// Start — 원본 토픽과 재시도 tier 토픽 루프를 모두 실행하고, 전부 종료될 때까지 대기.
+func (c *Consumer[T]) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, runner := range c.runners {
+		wg.Add(1)
+		go func(runner *Runner) {
+			defer wg.Done()
+			runner.Run(ctx)
+		}(runner)
+	}
+	wg.Wait()
+}
+
+func (c *Consumer[T]) Close() error {
+	var errs []error
+	for _, runner := range c.runners {
+		errs = append(errs, runner.Reader.Close())
+	}
+	if c.retry != nil {
+		errs = append(errs, c.retry.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Consumer[T]) handle(ctx context.Context, msg kafka.Message) (Result, error) {
+	// CloudEvents(binary/structured)면 봉투를 벗겨 이벤트 본문만 디코더에 넘김. 레거시 본문은 그대로.
+	payload, ce, err := cloudevents.Unwrap(msg)
+	if err != nil {
+		return c.deadLetter(ctx, msg, Result{Outcome: OutcomeDecodeError, Cause: err}, dlq.ErrorClassDecode, 0)
+	}
+	if ce == nil && c.opts.RequireCloudEvents {
+		err := errors.New("legacy payload rejected: CloudEvents required")
+		return c.deadLetter(ctx, msg, Result{Outcome: OutcomeRejected, Cause: err}, dlq.ErrorClassSchema, 0)
+	}
+	if ce != nil {
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.String("cloudevents.event_id", ce.ID),
+			attribute.String("cloudevents.event_type", ce.Type),
+			attribute.String("cloudevents.event_source", ce.Source),
+		)
+	}
+
+	// 디코더에는 이벤트 본문과 그 content-type만 (structured mode면 봉투의 datacontenttype)
+	decoded := msg
+	decoded.Value = payload
+	if ce != nil {
+		decoded.Headers = codec.WithContentType(msg.Headers, ce.DataContentType)
+	}
+	event, err := c.opts.Decode(decoded)
+	if err != nil {
+		// 디코드/스키마 실패는 재시도해도 같으므로 바로 DLQ (DLQ가 없으면 버림)
+		if errors.As(err, new(*schema.Error)) {
+			return c.deadLetter(ctx, msg, Result{Outcome: OutcomeRejected, Cause: err}, dlq.ErrorClassSchema, 0)
+		}
+		return c.deadLetter(ctx, msg, Result{Outcome: OutcomeDecodeError, Cause: err}, dlq.ErrorClassDecode, 0)
+	}
+	if c.opts.Attributes != nil {
+		trace.SpanFromContext(ctx).SetAttributes(c.opts.Attributes(event)...)
+	}
+
+	if c.opts.Validate != nil {
+		if err := c.opts.Validate(event); err != nil {
+			return c.deadLetter(ctx, msg, Result{Outcome: OutcomeRejected, Cause: err}, dlq.ErrorClassSchema, 0)
+		}
+	}
+
+	claim, err := c.claim(ctx, msg, ce)
+	if err != nil {
+		return Result{}, err
+	}
+	switch claim.Status {
+	case idempotency.Done:
+		if c.opts.Duplicate != nil {
+			if err := c.opts.Duplicate(ctx, msg, event); err != nil {
+				return Result{}, err
+			}
+		}
+		return Result{Outcome: OutcomeDuplicate}, nil
+	case idempotency.Busy:
+		// 다른 인스턴스가 처리 중 — 커밋하지 않고 Runner backoff 후 다시 (완료되면 duplicate)
+		return Result{}, fmt.Errorf("event %s is being processed by another consumer (%s)", claim.Key, c.opts.Topic)
+	}
+	if claim.Key != "" {
+		ctx = idempotency.WithClaim(ctx, claim)
+	}
+
+	attempts, handleErr := c.handleWithRetry(ctx, msg, event)
+	switch {
+	case handleErr == nil:
+		c.complete(ctx, claim)
+		return Result{Outcome: OutcomeOK}, nil
+	case errors.Is(handleErr, ErrRejected):
+		c.complete(ctx, claim)
+		return Result{Outcome: OutcomeRejected, Cause: handleErr}, nil
+	case errors.As(handleErr, new(incompleteError)):
+		// 재고 변경은 끝났을 수 있으므로 claim을 놓지 않고 같은 메시지 재처리에 그대로 사용
+		c.hold(msg, claim)
+		return Result{}, handleErr
+	case errors.Is(handleErr, errRetryInterrupted):
+		// 종료 중 — 재시도 토픽/DLQ 없이 lease만 놓고 커밋하지 않음 (재시작/리밸런스 후 다시 처리)
+		c.release(ctx, claim)
+		return Result{}, handleErr
+	}
+	// 재시도 토픽/DLQ로 넘기기 전에 lease 해제 (재처리가 바로 점유할 수 있도록)
+	c.release(ctx, claim)
+
+	attempts += retry.Attempts(msg)
+	if c.retry != nil {
+		retried, err := c.retry.Publish(ctx, msg, handleErr, attempts)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to publish to retry topic (%s): %w (processing error: %v)", c.opts.Topic, err, handleErr)
+		}
+		if retried {
+			return Result{Outcome: OutcomeRetried, Cause: handleErr}, nil
+		}
+	}
+
+	if c.opts.DLQ == nil {
+		return Result{}, handleErr
+	}
+	return c.deadLetter(ctx, msg, Result{Outcome: OutcomeDLQ, Cause: handleErr}, dlq.ErrorClassProcessing, attempts)
+}
+
+// deadLetter — DLQ로 원문과 출처/실패 정보를 보냄. DLQ가 없으면 result 그대로 (커밋 후 버림).
+func (c *Consumer[T]) deadLetter(ctx context.Context, msg kafka.Message, result Result, class string, attempts int) (Result, error) {
+	if c.opts.DLQ == nil {
+		return result, nil
+	}
+	err := c.opts.DLQ.Publish(ctx, msg, dlq.Failure{
+		Topic:         c.opts.Topic,
+		ConsumerGroup: c.opts.GroupID,
+		Class:         class,
+		Err:           result.Cause,
+		Attempts:      attempts,
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to publish to DLQ (%s): %w (%s error: %v)", c.opts.Topic, err, class, result.Cause)
+	}
+	return result, nil
+}
+
+// handleWithRetry — 실제 시도 횟수와 마지막 에러 반환. 재시도 대기 중 종료되면 errRetryInterrupted로 감싼 마지막 에러.
+func (c *Consumer[T]) handleWithRetry(ctx context.Context, msg kafka.Message, event T) (int, error) {
+	var err error
+	attempt := 1
+	for ; attempt <= c.opts.Retry.MaxAttempts; attempt++ {
+		err = c.opts.Handle(ctx, msg, event)
+		if err == nil || errors.Is(err, ErrRejected) || errors.As(err, new(incompleteError)) {
+			return attempt, err
+		}
+		if attempt < c.opts.Retry.MaxAttempts && c.opts.Retry.Delay != nil {
+			if !sleepContext(shutdownContext(ctx), c.opts.Retry.Delay(attempt)) {
+				return attempt, fmt.Errorf("%w: %w", errRetryInterrupted, err)
+			}
+		}
+	}
+	return attempt - 1, err
+}
+
+// eventID — CloudEvents id(source 포함), x-event-id 헤더, 둘 다 없으면 원본 토픽/파티션/오프셋
+// (재시도 tier를 거쳐도 같은 값).
+func (c *Consumer[T]) eventID(msg kafka.Message, ce *cloudevents.Event) string {
+	if ce != nil {
+		return ce.Source + "/" + ce.ID
+	}
+	for _, h := range msg.Headers {
+		if h.Key == kafkapkg.HeaderEventID && len(h.Value) > 0 {
+			return string(h.Value)
+		}
+	}
+	origin := retry.OriginOf(msg, c.opts.Topic)
+	return fmt.Sprintf("%s/%d/%d", origin.Topic, origin.Partition, origin.Offset)
+}
+
+func heldKey(msg kafka.Message) string {
+	return fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+}
+
+// claim — 멱등성 저장소가 없으면 키 없는 Claimed (완료/해제 생략).
+func (c *Consumer[T]) claim(ctx context.Context, msg kafka.Message, ce *cloudevents.Event) (idempotency.Claim, error) {
+	if c.opts.Idempotency == nil {
+		return idempotency.Claim{Status: idempotency.Claimed}, nil
+	}
+	c.mu.Lock()
+	claim, ok := c.held[heldKey(msg)]
+	delete(c.held, heldKey(msg))
+	c.mu.Unlock()
+	if ok {
+		return claim, nil
+	}
+
+	key := idempotency.Key(c.opts.GroupID, c.eventID(msg, ce))
+	claim, err := c.opts.Idempotency.Claim(ctx, key, c.opts.IdempotencyLease)
+	if err != nil {
+		return idempotency.Claim{}, fmt.Errorf("idempotency claim failed (%s): %w", c.opts.Topic, err)
+	}
+	return claim, nil
+}
+
+func (c *Consumer[T]) hold(msg kafka.Message, claim idempotency.Claim) {
+	if claim.Key == "" {
+		return
+	}
+	c.mu.Lock()
+	c.held[heldKey(msg)] = claim
+	c.mu.Unlock()
+}
+
+func (c *Consumer[T]) complete(ctx context.Context, claim idempotency.Claim) {
+	if claim.Key == "" {
+		return
+	}
+	if err := c.opts.Idempotency.Complete(ctx, claim); err != nil {
+		logger(c.opts.Topic).Error().Err(err).Str("idempotency_key", claim.Key).Msg("failed to mark message processed (idempotency)")
+	}
+}
+
+func (c *Consumer[T]) release(ctx context.Context, claim idempotency.Claim) {
+	if claim.Key == "" {
+		return
+	}
+	if err := c.opts.Idempotency.Release(ctx, claim); err != nil {
+		logger(c.opts.Topic).Error().Err(err).Str("idempotency_key", claim.Key).Msg("failed to release idempotency lease")
+	}
+}
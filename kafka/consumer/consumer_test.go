@@ -0,0 +1,115 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"productfc/config"
+	"productfc/infrastructure/log"
+	kafkapkg "productfc/kafka"
+	"productfc/models"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+)
+
+func TestMain(m *testing.M) {
+	logger := zerolog.Nop()
+	log.Logger = &logger
+	os.Exit(m.Run())
+}
+
+func TestNewRetryTiersFromConfig(t *testing.T) {
+	conn := &kafkapkg.Connection{Brokers: []string{"localhost:9092"}, Dialer: &kafka.Dialer{}}
+	tests := []struct {
+		name       string
+		tiers      []time.Duration
+		wantRetry  bool
+		wantReader int
+	}{
+		{name: "no tiers", wantReader: 1},
+		{name: "two tiers", tiers: []time.Duration{time.Second, time.Minute}, wantRetry: true, wantReader: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ConfigFor(config.KafkaConfig{RetryTiers: tt.tiers}, conn, "order_created", kafkapkg.TopicOrderCreated)
+			c := New(Options[models.OrderCreatedEvent]{
+				Config: cfg.withDefaultGroup("test-group"),
+				Handle: func(context.Context, kafka.Message, models.OrderCreatedEvent) error { return nil },
+			})
+			defer c.Close()
+
+			if got := c.retry != nil; got != tt.wantRetry {
+				t.Fatalf("retry publisher set = %v, want %v", got, tt.wantRetry)
+			}
+			if len(c.runners) != tt.wantReader {
+				t.Fatalf("runners = %d, want %d", len(c.runners), tt.wantReader)
+			}
+			if tt.wantRetry && c.opts.Retry.MaxAttempts != 1 {
+				t.Fatalf("in-process retry attempts = %d, want 1 with retry topics", c.opts.Retry.MaxAttempts)
+			}
+		})
+	}
+}
+
+func TestDLQIndexerConsumerConfig(t *testing.T) {
+	conn := &kafkapkg.Connection{Brokers: []string{"localhost:9092"}, Dialer: &kafka.Dialer{}}
+	kafkaCfg := config.KafkaConfig{
+		RetryTiers: []time.Duration{time.Minute, 10 * time.Minute},
+		Consumers:  map[string]config.KafkaConsumerConfig{"dlq_indexer": {GroupID: "productfc-dlq-indexer"}},
+	}
+	groups := make(map[string]bool)
+	for _, topic := range []string{kafkapkg.TopicDLQOrderCreated, kafkapkg.TopicDLQStockUpdated} {
+		c := NewDLQIndexerConsumer(ConfigFor(kafkaCfg, conn, "dlq_indexer", topic), nil, nil)
+		defer c.Close()
+
+		if c.retry != nil {
+			t.Fatalf("%s: retry publisher set, want none", topic)
+		}
+		if len(c.runners) != 1 {
+			t.Fatalf("%s: runners = %d, want 1", topic, len(c.runners))
+		}
+		if groups[c.opts.GroupID] {
+			t.Fatalf("%s: group %q shared with another DLQ topic", topic, c.opts.GroupID)
+		}
+		groups[c.opts.GroupID] = true
+	}
+}
+
+func TestInProcessRetryStopsOnShutdown(t *testing.T) {
+	calls := 0
+	c := New(Options[models.OrderCreatedEvent]{
+		Config: Config{
+			Conn:    &kafkapkg.Connection{Brokers: []string{"localhost:9092"}, Dialer: &kafka.Dialer{}},
+			Topic:   kafkapkg.TopicOrderCreated,
+			GroupID: "test-group",
+		},
+		Handle: func(context.Context, kafka.Message, models.OrderCreatedEvent) error {
+			calls++
+			return errors.New("temporary failure")
+		},
+		Retry:      RetryPolicy{MaxAttempts: 3, Delay: func(int) time.Duration { return time.Hour }},
+		Middleware: []Middleware{},
+	})
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	done := make(chan bool, 1)
+	go func() { done <- c.runners[0].handleUntilDone(ctx, kafka.Message{Value: []byte(`{}`)}) }()
+	select {
+	case ok := <-done:
+		if ok {
+			t.Fatal("handleUntilDone = true, want false after shutdown")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-process retry backoff ignored shutdown")
+	}
+	if calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,35 @@
+package consumer
+
+import (
+	"context"
+
+	dlqservice "productfc/cmd/dlq/service"
+	"productfc/infrastructure/kafkamonitor"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// NewDLQIndexerConsumer — *.dlq 토픽을 읽어 조회/재처리용 dlq_messages 테이블에 적재.
+// 저장 실패는 DLQ로 보내지 않고 커밋 없이 재시도. 재시도 토픽으로 넘기면 dlq_topic/partition/offset이
+// 재시도 토픽 위치로 저장돼 중복 제거가 깨지므로 retry tier는 항상 끔.
+// DLQ 토픽마다 reader가 따로라 그룹도 토픽별(<group>-<topic>)로 나눠 서로 리밸런스하지 않게 함.
+func NewDLQIndexerConsumer(
+	cfg Config,
+	dlqService *dlqservice.DLQService,
+	mon *kafkamonitor.Monitor,
+) *Consumer[kafka.Message] {
+	cfg = cfg.withDefaultGroup("productfc-dlq-indexer")
+	cfg.GroupID += "-" + cfg.Topic
+	cfg.RetryTiers = nil
+	return New(Options[kafka.Message]{
+		Config: cfg,
+		// 이벤트 = CloudEvents 봉투를 벗긴 메시지 (DLQ 토픽이 structured mode여도 래핑 본문을 읽음)
+		Decode: func(msg kafka.Message) (kafka.Message, error) {
+			return msg, nil
+		},
+		Handle: func(ctx context.Context, _ kafka.Message, msg kafka.Message) error {
+			return dlqService.IngestMessage(ctx, msg)
+		},
+		Monitor: mon,
+	})
+}
@@ -0,0 +1,103 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"productfc/infrastructure/kafkamonitor"
+	"productfc/infrastructure/log"
+	"productfc/tracing"
+
+	"github.com/rs/zerolog"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware — 메시지 헤더의 트레이스 컨텍스트를 부모로 consumer span 생성, 결과/에러 기록.
+func TracingMiddleware(topic, groupID string) Middleware {
+	return func(next ProcessFunc) ProcessFunc {
+		return func(ctx context.Context, msg kafka.Message) (Result, error) {
+			ctx = tracing.ExtractKafka(ctx, msg)
+			ctx, span := tracing.StartSpan(ctx, "kafka.consume."+topic,
+				trace.WithSpanKind(trace.SpanKindConsumer))
+			defer span.End()
+			span.SetAttributes(
+				semconv.MessagingSystem("kafka"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingDestinationName(msg.Topic),
+				semconv.MessagingKafkaConsumerGroup(groupID),
+				semconv.MessagingKafkaDestinationPartition(msg.Partition),
+				semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			)
+			if len(msg.Key) > 0 {
+				span.SetAttributes(semconv.MessagingKafkaMessageKey(string(msg.Key)))
+			}
+
+			result, err := next(ctx, msg)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return result, err
+			}
+			span.SetAttributes(attribute.String("messaging.outcome", string(result.Outcome)))
+			if result.Cause != nil {
+				span.RecordError(result.Cause)
+			}
+			return result, nil
+		}
+	}
+}
+
+// MetricsMiddleware — 토픽/결과별 처리 건수와 처리 시간 기록 (kafkamonitor + Prometheus).
+func MetricsMiddleware(topic string, mon *kafkamonitor.Monitor) Middleware {
+	return func(next ProcessFunc) ProcessFunc {
+		return func(ctx context.Context, msg kafka.Message) (Result, error) {
+			start := time.Now()
+			result, err := next(ctx, msg)
+			if err == nil {
+				mon.Record(topic, string(result.Outcome))
+				mon.ObserveProcessing(topic, string(result.Outcome), time.Since(start))
+			}
+			return result, err
+		}
+	}
+}
+
+// LoggingMiddleware — 결과별 로그 (ok는 debug, 거절/디코드 실패는 warn, DLQ는 error).
+// 처리 미완료 에러는 Runner가 재시도하면서 기록.
+func LoggingMiddleware(topic string) Middleware {
+	return func(next ProcessFunc) ProcessFunc {
+		return func(ctx context.Context, msg kafka.Message) (Result, error) {
+			result, err := next(ctx, msg)
+			if err != nil {
+				return result, err
+			}
+
+			var event *zerolog.Event
+			switch result.Outcome {
+			case OutcomeOK:
+				event = logger(topic).Debug()
+			case OutcomeDuplicate:
+				event = logger(topic).Info()
+			case OutcomeDLQ:
+				event = logger(topic).Error()
+			default:
+				event = logger(topic).Warn()
+			}
+			event.Err(result.Cause).
+				Int("partition", msg.Partition).
+				Int64("offset", msg.Offset).
+				Str("outcome", string(result.Outcome)).
+				Msg("Kafka message handled")
+			return result, nil
+		}
+	}
+}
+
+func logger(topic string) *zerolog.Logger {
+	l := log.Logger.With().Str("topic", topic).Logger()
+	return &l
+}
@@ -2,118 +2,161 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"productfc/cmd/product/service"
 	"productfc/infrastructure/kafkamonitor"
-	"productfc/infrastructure/log"
 	kafkapkg "productfc/kafka"
 	"productfc/kafka/dlq"
 	"productfc/kafka/idempotency"
+	"productfc/kafka/schema"
 	"productfc/models"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-type OrderCreatedConsumer struct {
-	Reader         *kafka.Reader
-	ProductService *service.ProductService
-	Producer       *kafkapkg.Producer
-	Idempotency    *idempotency.Store
-	DLQ            *dlq.Publisher
-	Monitor        *kafkamonitor.Monitor
+// orderCreatedHandler — 재고 차감 후 stock.reserved / stock.rejected 발행.
+type orderCreatedHandler struct {
+	productService *service.ProductService
+	producer       *kafkapkg.Producer
+
+	mu      sync.Mutex
+	pending map[string]models.StockReservationEvent
 }
 
 func NewOrderCreatedConsumer(
-	brokers []string,
-	topic string,
+	cfg Config,
 	productService *service.ProductService,
 	producer *kafkapkg.Producer,
-	idem *idempotency.Store,
+	idem idempotency.Store,
 	dlqPub *dlq.Publisher,
 	mon *kafkamonitor.Monitor,
-) *OrderCreatedConsumer {
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: brokers,
-		Topic:   topic,
-		GroupID: "productfc-order-created",
-	})
-	return &OrderCreatedConsumer{
-		Reader:         reader,
-		ProductService: productService,
-		Producer:       producer,
-		Idempotency:    idem,
-		DLQ:            dlqPub,
-		Monitor:        mon,
+) *Consumer[models.OrderCreatedEvent] {
+	h := &orderCreatedHandler{
+		productService: productService,
+		producer:       producer,
+		pending:        make(map[string]models.StockReservationEvent),
 	}
+	return New(Options[models.OrderCreatedEvent]{
+		Config: cfg.withDefaultGroup("productfc-order-created"),
+		Decode: SchemaDecoder[models.OrderCreatedEvent](schema.OrderCreated),
+		Validate: func(event models.OrderCreatedEvent) error {
+			_, err := models.AssignLineIDs(event.Products)
+			return err
+		},
+		Attributes: func(event models.OrderCreatedEvent) []attribute.KeyValue {
+			return []attribute.KeyValue{attribute.Int64("order.id", event.OrderID)}
+		},
+		Handle:      h.handle,
+		Duplicate:   h.republish,
+		Idempotency: idem,
+		DLQ:         dlqPub,
+		Monitor:     mon,
+	})
 }
 
-func (c *OrderCreatedConsumer) Start(ctx context.Context) {
-	for {
-		msg, err := c.Reader.ReadMessage(ctx)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to read message from Kafka (order.created)")
-			continue
-		}
+func (h *orderCreatedHandler) handle(ctx context.Context, msg kafka.Message, event models.OrderCreatedEvent) error {
+	// 재고 차감 후 결과 발행만 실패해 재시도 중인 메시지면 차감을 반복하지 않고 발행부터
+	if pending, ok := h.takePending(msg); ok {
+		return h.publishReservation(ctx, msg, pending)
+	}
 
-		var event models.OrderCreatedEvent
-		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to unmarshal order.created")
-			if c.Monitor != nil {
-				c.Monitor.IncUnmarshalErr()
-			}
-			continue
-		}
+	reservationEvent := models.StockReservationEvent{
+		SchemaVersion: kafkapkg.SchemaVersionStockEvent,
+		OrderID:       event.OrderID,
+		UserID:        event.UserID,
+		TotalAmount:   event.TotalAmount,
+		Products:      event.Products,
+		EventTime:     time.Now(),
+	}
 
-		processed, err := c.Idempotency.AlreadyProcessed(ctx, kafkapkg.TopicOrderCreated, event.OrderID)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("idempotency check failed (order.created)")
-			continue
+	reservation, err := h.productService.ReserveOrderStocks(ctx, event.OrderID, event.Products)
+	if err != nil {
+		var insufficient *models.InsufficientStockError
+		if !errors.As(err, &insufficient) {
+			return err
 		}
-		if processed {
-			continue
+		reservationEvent.Reason = err.Error()
+		reservationEvent.Shortfalls = insufficient.Shortfalls
+		if err := h.publishReservation(ctx, msg, reservationEvent); err != nil {
+			return err
 		}
+		return fmt.Errorf("%w: %s", ErrRejected, reservationEvent.Reason)
+	}
 
-		reservationEvent := models.StockReservationEvent{
-			SchemaVersion: kafkapkg.SchemaVersionStockEvent,
-			OrderID:       event.OrderID,
-			UserID:        event.UserID,
-			TotalAmount:   event.TotalAmount,
-			Products:      event.Products,
-			EventTime:     time.Now(),
-		}
+	// 부분 롤백에서 참조할 예약 ID와 라인 ID
+	reservationEvent.ReservationID = reservation.ID
+	reservationEvent.Products = reservation.Items()
+	// 부분 충족이면 stock.reserved에 예약하지 못한 나머지
+	reservationEvent.Shortfalls = reservation.Shortfalls
+	return h.publishReservation(ctx, msg, reservationEvent)
+}
 
-		if err := c.ProductService.UpdateProductStocks(ctx, event.Products); err != nil {
-			if errors.Is(err, models.ErrInsufficientStock) {
-				reservationEvent.Reason = err.Error()
-				if publishErr := c.Producer.PublishStockRejected(ctx, reservationEvent); publishErr != nil {
-					log.Logger.Error().Err(publishErr).Int64("order_id", event.OrderID).Msg("failed to publish stock.rejected")
-					continue
-				}
-				if err := c.Idempotency.MarkProcessed(ctx, kafkapkg.TopicOrderCreated, event.OrderID); err != nil {
-					log.Logger.Error().Err(err).Msg("failed to mark order.created processed after stock rejection")
-				}
-				continue
-			}
-
-			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("stock reservation failed")
-			if c.DLQ != nil {
-				if dlqErr := c.DLQ.Publish(ctx, kafkapkg.TopicOrderCreated, msg.Value, err); dlqErr != nil {
-					log.Logger.Error().Err(dlqErr).Msg("failed to publish to DLQ (order.created)")
-				}
-			}
-			continue
-		}
+// republish — 이미 완료된 주문을 다시 받으면 커밋된 예약으로 stock.reserved 재발행.
+// 예약과 완료 기록은 한 트랜잭션이지만 발행은 그 뒤라, 발행 전에 재시작했으면 여기서만 복구됨
+// (이미 발행됐다면 중복 — 구독자는 reservation_id로 멱등 처리). 거절된 주문은 예약이 없어 건너뜀.
+func (h *orderCreatedHandler) republish(ctx context.Context, msg kafka.Message, event models.OrderCreatedEvent) error {
+	reservation, err := h.productService.FindOrderReservation(ctx, event.OrderID)
+	if errors.Is(err, models.ErrReservationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return h.publish(ctx, models.StockReservationEvent{
+		SchemaVersion: kafkapkg.SchemaVersionStockEvent,
+		OrderID:       event.OrderID,
+		UserID:        event.UserID,
+		TotalAmount:   event.TotalAmount,
+		Products:      reservation.Items(),
+		EventTime:     reservation.CreatedAt,
+		ReservationID: reservation.ID,
+		Shortfalls:    reservation.ShortfallsFor(event.Products),
+	})
+}
 
-		if err := c.Producer.PublishStockReserved(ctx, reservationEvent); err != nil {
-			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("failed to publish stock.reserved")
-			continue
-		}
+// publishReservation — stock.reserved/stock.rejected 발행.
+// 발행 실패 시 재고 변경 결과를 기억해 두고 Incomplete 반환 (커밋 없이 같은 메시지 재처리).
+func (h *orderCreatedHandler) publishReservation(ctx context.Context, msg kafka.Message, event models.StockReservationEvent) error {
+	if err := h.publish(ctx, event); err != nil {
+		h.setPending(msg, event)
+		return Incomplete(err)
+	}
+	return nil
+}
 
-		if err := c.Idempotency.MarkProcessed(ctx, kafkapkg.TopicOrderCreated, event.OrderID); err != nil {
-			log.Logger.Error().Err(err).Msg("failed to mark order.created processed after stock reservation")
-		}
+func (h *orderCreatedHandler) publish(ctx context.Context, event models.StockReservationEvent) error {
+	publish, topic := h.producer.PublishStockReserved, h.producer.Topics().StockReserved
+	if event.Reason != "" {
+		publish, topic = h.producer.PublishStockRejected, h.producer.Topics().StockRejected
+	}
+	if err := publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish %s for order %d: %w", topic, event.OrderID, err)
+	}
+	return nil
+}
+
+// pendingKey — 원본 토픽과 재시도 tier 토픽을 같은 핸들러가 소비하므로 토픽까지 포함 (heldKey와 같은 형식).
+func pendingKey(msg kafka.Message) string {
+	return fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+}
+
+func (h *orderCreatedHandler) setPending(msg kafka.Message, event models.StockReservationEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending[pendingKey(msg)] = event
+}
+
+func (h *orderCreatedHandler) takePending(msg kafka.Message) (models.StockReservationEvent, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	event, ok := h.pending[pendingKey(msg)]
+	if ok {
+		delete(h.pending, pendingKey(msg))
 	}
+	return event, ok
 }
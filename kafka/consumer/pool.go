@@ -0,0 +1,169 @@
+package consumer
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const defaultMaxInFlightPerWorker = 16
+
+// pool — 메시지 키(주문 ID) 해시로 워커를 고정해 같은 키는 순서대로, 다른 키는 동시에 처리.
+// 커밋은 파티션별로 앞에서부터 연속으로 완료된 오프셋까지만 전진.
+type pool struct {
+	runner   *Runner
+	queues   []chan kafka.Message
+	inFlight chan struct{}
+	tracker  *offsetTracker
+	wg       sync.WaitGroup
+}
+
+// newPool — 워커 시작. 워커는 ctx 취소 후에도 큐에 받은 메시지를 끝까지 처리 (wait로 대기).
+func newPool(ctx context.Context, r *Runner) *pool {
+	maxInFlight := r.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = r.Workers * defaultMaxInFlightPerWorker
+	}
+	p := &pool{
+		runner:   r,
+		queues:   make([]chan kafka.Message, r.Workers),
+		inFlight: make(chan struct{}, maxInFlight),
+		tracker:  newOffsetTracker(),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan kafka.Message, maxInFlight)
+		p.wg.Add(1)
+		go p.work(ctx, p.queues[i])
+	}
+	return p
+}
+
+// dispatch — in-flight 자리가 날 때까지 대기(backpressure) 후 키 담당 워커 큐에 넣음.
+// 대기 중 ctx가 취소되면 false (메시지는 커밋되지 않아 재시작 후 다시 읽음).
+func (p *pool) dispatch(ctx context.Context, msg kafka.Message) bool {
+	select {
+	case p.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	p.tracker.add(msg)
+	p.queues[p.workerFor(msg)] <- msg
+	return true
+}
+
+// wait — 큐를 닫고 워커가 받은 메시지를 모두 처리할 때까지 대기 (Run 종료 시).
+func (p *pool) wait() {
+	for _, q := range p.queues {
+		close(q)
+	}
+	p.wg.Wait()
+}
+
+func (p *pool) workerFor(msg kafka.Message) int {
+	h := fnv.New32a()
+	if len(msg.Key) > 0 {
+		h.Write(msg.Key)
+	} else {
+		// 키 없는 메시지는 파티션 순서 유지
+		h.Write([]byte{byte(msg.Partition >> 24), byte(msg.Partition >> 16), byte(msg.Partition >> 8), byte(msg.Partition)})
+	}
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+// work — 한 메시지가 종료 중 미완료로 끝나면 큐의 나머지는 처리하지 않고 비움.
+// 뒤의 같은 키 메시지(예: 롤백)가 앞 메시지보다 먼저 적용되면 키별 순서가 깨지기 때문
+// (커밋되지 않으므로 재시작 후 미완료 메시지부터 다시 읽음).
+func (p *pool) work(ctx context.Context, queue <-chan kafka.Message) {
+	defer p.wg.Done()
+	stopped := false
+	for msg := range queue {
+		if stopped {
+			<-p.inFlight
+			continue
+		}
+		done := p.runner.handleUntilDone(ctx, msg)
+		<-p.inFlight
+		if !done {
+			// 종료 중 미완료 — 이 파티션은 이 오프셋 앞까지만 커밋됨
+			stopped = true
+			continue
+		}
+		if commit, ok := p.tracker.complete(msg); ok {
+			p.tracker.commit(commit, p.runner.commit)
+		}
+	}
+}
+
+// offsetTracker — 파티션별로 읽은 오프셋 순서와 완료 여부를 추적.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[partitionKey]*partitionOffsets
+
+	commitMu  sync.Mutex
+	committed map[partitionKey]int64
+}
+
+type partitionKey struct {
+	topic     string
+	partition int
+}
+
+type partitionOffsets struct {
+	pending []kafka.Message // 읽은 순서 (오프셋 오름차순)
+	done    map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[partitionKey]*partitionOffsets),
+		committed:  make(map[partitionKey]int64),
+	}
+}
+
+func (t *offsetTracker) add(msg kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := partitionKey{topic: msg.Topic, partition: msg.Partition}
+	po, ok := t.partitions[key]
+	if !ok {
+		po = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[key] = po
+	}
+	po.pending = append(po.pending, msg)
+}
+
+// complete — msg 완료 표시 후 앞에서부터 연속 완료된 구간을 제거하고, 커밋할 마지막 메시지 반환.
+func (t *offsetTracker) complete(msg kafka.Message) (kafka.Message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	po := t.partitions[partitionKey{topic: msg.Topic, partition: msg.Partition}]
+	if po == nil {
+		return kafka.Message{}, false
+	}
+	po.done[msg.Offset] = true
+
+	var last kafka.Message
+	advanced := false
+	for len(po.pending) > 0 && po.done[po.pending[0].Offset] {
+		last = po.pending[0]
+		delete(po.done, last.Offset)
+		po.pending = po.pending[1:]
+		advanced = true
+	}
+	return last, advanced
+}
+
+// commit — 워커끼리 커밋 순서가 뒤바뀌어 오프셋이 후퇴하지 않도록 직렬화.
+func (t *offsetTracker) commit(msg kafka.Message, commit func(...kafka.Message)) {
+	t.commitMu.Lock()
+	defer t.commitMu.Unlock()
+	key := partitionKey{topic: msg.Topic, partition: msg.Partition}
+	if prev, ok := t.committed[key]; ok && prev >= msg.Offset {
+		return
+	}
+	commit(msg)
+	t.committed[key] = msg.Offset
+}
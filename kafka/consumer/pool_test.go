@@ -0,0 +1,276 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func msgAt(topic string, partition int, offset int64) kafka.Message {
+	return kafka.Message{Topic: topic, Partition: partition, Offset: offset}
+}
+
+func TestOffsetTrackerContiguousCommit(t *testing.T) {
+	type step struct {
+		complete kafka.Message
+		want     int64 // 커밋할 오프셋, -1이면 커밋 없음
+	}
+	tests := []struct {
+		name  string
+		read  []kafka.Message
+		steps []step
+	}{
+		{
+			name: "in order",
+			read: []kafka.Message{msgAt("t", 0, 0), msgAt("t", 0, 1), msgAt("t", 0, 2)},
+			steps: []step{
+				{msgAt("t", 0, 0), 0},
+				{msgAt("t", 0, 1), 1},
+				{msgAt("t", 0, 2), 2},
+			},
+		},
+		{
+			name: "out of order holds until head completes",
+			read: []kafka.Message{msgAt("t", 0, 0), msgAt("t", 0, 1), msgAt("t", 0, 2)},
+			steps: []step{
+				{msgAt("t", 0, 2), -1},
+				{msgAt("t", 0, 1), -1},
+				{msgAt("t", 0, 0), 2},
+			},
+		},
+		{
+			name: "middle completes last",
+			read: []kafka.Message{msgAt("t", 0, 0), msgAt("t", 0, 1), msgAt("t", 0, 2), msgAt("t", 0, 3)},
+			steps: []step{
+				{msgAt("t", 0, 0), 0},
+				{msgAt("t", 0, 2), -1},
+				{msgAt("t", 0, 3), -1},
+				{msgAt("t", 0, 1), 3},
+			},
+		},
+		{
+			name: "offset gaps (compaction/transaction markers) do not block",
+			read: []kafka.Message{msgAt("t", 0, 10), msgAt("t", 0, 13), msgAt("t", 0, 20)},
+			steps: []step{
+				{msgAt("t", 0, 13), -1},
+				{msgAt("t", 0, 10), 13},
+				{msgAt("t", 0, 20), 20},
+			},
+		},
+		{
+			name: "partitions are independent",
+			read: []kafka.Message{msgAt("t", 0, 0), msgAt("t", 1, 0), msgAt("t", 0, 1), msgAt("t", 1, 1)},
+			steps: []step{
+				{msgAt("t", 1, 1), -1},
+				{msgAt("t", 0, 0), 0},
+				{msgAt("t", 1, 0), 1},
+				{msgAt("t", 0, 1), 1},
+			},
+		},
+		{
+			name: "same partition on different topics is independent",
+			read: []kafka.Message{msgAt("a", 0, 5), msgAt("b", 0, 5), msgAt("a", 0, 6)},
+			steps: []step{
+				{msgAt("a", 0, 6), -1},
+				{msgAt("b", 0, 5), 5},
+				{msgAt("a", 0, 5), 6},
+			},
+		},
+		{
+			name: "unknown partition",
+			read: []kafka.Message{msgAt("t", 0, 0)},
+			steps: []step{
+				{msgAt("t", 3, 0), -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			for _, msg := range tt.read {
+				tracker.add(msg)
+			}
+			for i, s := range tt.steps {
+				got, ok := tracker.complete(s.complete)
+				switch {
+				case s.want < 0 && ok:
+					t.Fatalf("step %d: committed %s/%d/%d, want no commit", i, got.Topic, got.Partition, got.Offset)
+				case s.want >= 0 && !ok:
+					t.Fatalf("step %d: no commit, want offset %d", i, s.want)
+				case ok && (got.Offset != s.want || got.Topic != s.complete.Topic || got.Partition != s.complete.Partition):
+					t.Fatalf("step %d: committed %s/%d/%d, want %s/%d/%d", i, got.Topic, got.Partition, got.Offset,
+						s.complete.Topic, s.complete.Partition, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOffsetTrackerCommitNeverRegresses(t *testing.T) {
+	tracker := newOffsetTracker()
+	var committed []int64
+	commit := func(msgs ...kafka.Message) {
+		for _, msg := range msgs {
+			committed = append(committed, msg.Offset)
+		}
+	}
+
+	tracker.commit(msgAt("t", 0, 5), commit)
+	tracker.commit(msgAt("t", 0, 3), commit)
+	tracker.commit(msgAt("t", 0, 5), commit)
+	tracker.commit(msgAt("t", 0, 7), commit)
+	tracker.commit(msgAt("t", 1, 1), commit)
+
+	want := []int64{5, 7, 1}
+	if len(committed) != len(want) {
+		t.Fatalf("committed %v, want %v", committed, want)
+	}
+	for i := range want {
+		if committed[i] != want[i] {
+			t.Fatalf("committed %v, want %v", committed, want)
+		}
+	}
+}
+
+// 워커 여러 개가 임의 순서로 완료해도 파티션별 커밋은 단조 증가하고 마지막 오프셋에서 끝나야 함 (-race로 실행).
+func TestOffsetTrackerConcurrentCompletion(t *testing.T) {
+	const partitions, perPartition = 4, 200
+	tracker := newOffsetTracker()
+	var msgs []kafka.Message
+	for p := 0; p < partitions; p++ {
+		for o := int64(0); o < perPartition; o++ {
+			msg := msgAt("t", p, o)
+			tracker.add(msg)
+			msgs = append(msgs, msg)
+		}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(msgs), func(i, j int) { msgs[i], msgs[j] = msgs[j], msgs[i] })
+
+	var mu sync.Mutex
+	last := make(map[int]int64)
+	regressed := false
+	commit := func(batch ...kafka.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, msg := range batch {
+			if prev, ok := last[msg.Partition]; ok && msg.Offset <= prev {
+				regressed = true
+			}
+			last[msg.Partition] = msg.Offset
+		}
+	}
+
+	var wg sync.WaitGroup
+	work := make(chan kafka.Message)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range work {
+				if c, ok := tracker.complete(msg); ok {
+					tracker.commit(c, commit)
+				}
+			}
+		}()
+	}
+	for _, msg := range msgs {
+		work <- msg
+	}
+	close(work)
+	wg.Wait()
+
+	if regressed {
+		t.Fatal("committed offset regressed")
+	}
+	for p := 0; p < partitions; p++ {
+		if last[p] != perPartition-1 {
+			t.Fatalf("partition %d committed %d, want %d", p, last[p], perPartition-1)
+		}
+	}
+}
+
+// 종료 중 한 키의 메시지가 미완료로 끝나면 같은 워커 큐의 뒤 메시지는 처리하지 않아야 함.
+func TestPoolStopsWorkerQueueAfterUnfinishedMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		messages    []kafka.Message
+		failOffset  int64
+		wantHandled []int64
+	}{
+		{
+			name: "later message of the same key",
+			messages: []kafka.Message{
+				{Topic: "t", Key: []byte("order-1"), Offset: 0},
+				{Topic: "t", Key: []byte("order-1"), Offset: 1},
+				{Topic: "t", Key: []byte("order-1"), Offset: 2},
+			},
+			failOffset:  1,
+			wantHandled: []int64{0, 1},
+		},
+		{
+			name: "first message unfinished",
+			messages: []kafka.Message{
+				{Topic: "t", Key: []byte("order-1"), Offset: 0},
+				{Topic: "t", Key: []byte("order-1"), Offset: 1},
+				{Topic: "t", Key: []byte("order-2"), Offset: 2},
+			},
+			failOffset:  0,
+			wantHandled: []int64{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var handled []int64
+			failing := make(chan struct{})
+			r := &Runner{
+				Name:    "test",
+				Reader:  kafka.NewReader(kafka.ReaderConfig{Brokers: []string{"localhost:9092"}, Topic: "t"}),
+				Workers: 1,
+				Backoff: Backoff{Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1},
+				Handle: func(ctx context.Context, msg kafka.Message) error {
+					mu.Lock()
+					defer mu.Unlock()
+					if len(handled) == 0 || handled[len(handled)-1] != msg.Offset {
+						handled = append(handled, msg.Offset)
+					}
+					if msg.Offset == tt.failOffset {
+						select {
+						case <-failing:
+						default:
+							close(failing)
+						}
+						return errors.New("handler failed")
+					}
+					return nil
+				},
+			}
+			defer r.Reader.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			p := newPool(ctx, r)
+			for _, msg := range tt.messages {
+				if !p.dispatch(ctx, msg) {
+					t.Fatalf("dispatch offset %d failed", msg.Offset)
+				}
+			}
+			<-failing
+			cancel()
+			p.wait()
+
+			if len(handled) != len(tt.wantHandled) {
+				t.Fatalf("handled offsets %v, want %v", handled, tt.wantHandled)
+			}
+			for i := range tt.wantHandled {
+				if handled[i] != tt.wantHandled[i] {
+					t.Fatalf("handled offsets %v, want %v", handled, tt.wantHandled)
+				}
+			}
+		})
+	}
+}
@@ -2,114 +2,44 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
-	"time"
+	"errors"
+	"fmt"
 
 	"productfc/cmd/product/service"
 	"productfc/infrastructure/kafkamonitor"
-	"productfc/infrastructure/log"
-	kafkapkg "productfc/kafka"
 	"productfc/kafka/dlq"
 	"productfc/kafka/idempotency"
+	"productfc/kafka/schema"
 	"productfc/models"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-type ProductRollbackStockConsumer struct {
-	Reader         *kafka.Reader
-	ProductService *service.ProductService
-	Idempotency    *idempotency.Store
-	DLQ            *dlq.Publisher
-	Monitor        *kafkamonitor.Monitor
-}
-
 func NewProductRollbackStockConsumer(
-	brokers []string,
-	topic string,
+	cfg Config,
 	productService *service.ProductService,
-	idem *idempotency.Store,
+	idem idempotency.Store,
 	dlqPub *dlq.Publisher,
 	mon *kafkamonitor.Monitor,
-) *ProductRollbackStockConsumer {
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: brokers,
-		Topic:   topic,
-		GroupID: "productfc-stock-rollback",
-	})
-	return &ProductRollbackStockConsumer{
-		Reader:         reader,
-		ProductService: productService,
-		Idempotency:    idem,
-		DLQ:            dlqPub,
-		Monitor:        mon,
-	}
-}
-
-func (c *ProductRollbackStockConsumer) Start(ctx context.Context) {
-	for {
-		msg, err := c.Reader.ReadMessage(ctx)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to read message from Kafka (stock.rollback)")
-			continue
-		}
-
-		var event models.ProductStockRollbackEvent
-		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to unmarshal stock.rollback")
-			if c.Monitor != nil {
-				c.Monitor.IncUnmarshalErr()
-			}
-			continue
-		}
-
-		if event.SchemaVersion > kafkapkg.SchemaVersionStockEvent {
-			log.Logger.Warn().Int("schema_version", event.SchemaVersion).Msg("Unsupported schema_version for stock.rollback")
-			if c.Monitor != nil {
-				c.Monitor.IncSchemaRejected()
+) *Consumer[models.ProductStockRollbackEvent] {
+	return New(Options[models.ProductStockRollbackEvent]{
+		Config: cfg.withDefaultGroup("productfc-stock-rollback"),
+		Decode: SchemaDecoder[models.ProductStockRollbackEvent](schema.StockRollback),
+		Attributes: func(event models.ProductStockRollbackEvent) []attribute.KeyValue {
+			return []attribute.KeyValue{attribute.Int64("order.id", event.OrderID)}
+		},
+		Handle: func(ctx context.Context, msg kafka.Message, event models.ProductStockRollbackEvent) error {
+			err := productService.RestoreReservedStocks(ctx, event)
+			// 예약 한도 초과/알 수 없는 라인은 다시 처리해도 같으므로 거절로 종료
+			if errors.Is(err, models.ErrReservationNotFound) || errors.Is(err, models.ErrInvalidRollback) ||
+				errors.Is(err, models.ErrRestoreExceedsReserved) {
+				return fmt.Errorf("%w: %v", ErrRejected, err)
 			}
-			continue
-		}
-
-		processed, err := c.Idempotency.AlreadyProcessed(ctx, kafkapkg.TopicStockRollback, event.OrderID)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("idempotency check failed (stock.rollback)")
-			continue
-		}
-		if processed {
-			if c.Monitor != nil {
-				c.Monitor.IncRollbackDup()
-			}
-			continue
-		}
-
-		var lastErr error
-		for attempt := 0; attempt < 3; attempt++ {
-			lastErr = c.ProductService.AddProductStocks(ctx, event.Products)
-			if lastErr == nil {
-				break
-			}
-			time.Sleep(time.Duration(50*(attempt+1)) * time.Millisecond)
-		}
-
-		if lastErr != nil {
-			log.Logger.Error().Err(lastErr).Int64("order_id", event.OrderID).Msg("stock.rollback processing failed after retries")
-			if c.DLQ != nil {
-				if err := c.DLQ.Publish(ctx, kafkapkg.TopicStockRollback, msg.Value, lastErr); err != nil {
-					log.Logger.Error().Err(err).Msg("failed to publish to DLQ (stock.rollback)")
-				} else if c.Monitor != nil {
-					c.Monitor.IncRollbackDLQ()
-				}
-			}
-			continue
-		}
-
-		if err := c.Idempotency.MarkProcessed(ctx, kafkapkg.TopicStockRollback, event.OrderID); err != nil {
-			log.Logger.Error().Err(err).Msg("failed to mark stock.rollback processed (idempotency)")
-		}
-		if c.Monitor != nil {
-			c.Monitor.IncRollbackOK()
-		}
-		log.Logger.Info().Int64("order_id", event.OrderID).Msg("Product stock rollback applied")
-	}
+			return err
+		},
+		Idempotency: idem,
+		DLQ:         dlqPub,
+		Monitor:     mon,
+	})
 }
@@ -0,0 +1,200 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"time"
+
+	"productfc/infrastructure/kafkamonitor"
+	"productfc/infrastructure/log"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Backoff — 읽기 실패 시 지수 백오프 (full jitter: 0 ~ min(Max, Initial*Multiplier^n) 사이 임의 대기).
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+var DefaultBackoff = Backoff{
+	Initial:    200 * time.Millisecond,
+	Max:        30 * time.Second,
+	Multiplier: 2,
+}
+
+func (b Backoff) Duration(attempt int) time.Duration {
+	ceiling := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		ceiling *= b.Multiplier
+		if ceiling >= float64(b.Max) {
+			ceiling = float64(b.Max)
+			break
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling)) + 1)
+}
+
+const statsInterval = 15 * time.Second
+
+// HandleFunc — nil이면 메시지 처리 완료(성공/거절/DLQ)로 보고 오프셋 커밋.
+// 에러면 처리 미완료 — 커밋하지 않고 같은 메시지를 백오프 후 다시 처리.
+type HandleFunc func(ctx context.Context, msg kafka.Message) error
+
+// Runner — 공통 컨슈머 루프. ctx 취소/reader 종료 시 반환하고, 일시적 읽기 오류는 백오프 후 재시도.
+// ReadMessage(자동 커밋) 대신 FetchMessage로 읽고 처리 완료 후에만 CommitMessages.
+// 상태(running/backing_off/stopped)는 Monitor를 통해 /debug/kafka에 노출.
+type Runner struct {
+	Name    string
+	Reader  *kafka.Reader
+	Handle  HandleFunc
+	Backoff Backoff
+	Monitor *kafkamonitor.Monitor
+
+	// Delay — 처리 전 대기 시간 (재시도 토픽의 not-before). 대기 중 종료되면 커밋 없이 반환.
+	Delay func(msg kafka.Message) time.Duration
+
+	// Workers > 1이면 키별 순서를 지키며 동시 처리 (pool.go). MaxInFlight는 읽었지만
+	// 처리가 끝나지 않은 메시지 상한 — 차면 다음 메시지를 읽지 않고 대기.
+	Workers     int
+	MaxInFlight int
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	r.setState(kafkamonitor.StateRunning, nil, 0)
+	defer r.setState(kafkamonitor.StateStopped, nil, 0)
+
+	statsCtx, stopStats := context.WithCancel(ctx)
+	defer stopStats()
+	go r.collectStats(statsCtx)
+
+	dispatch := r.process
+	if r.Workers > 1 {
+		p := newPool(ctx, r)
+		defer p.wait()
+		dispatch = p.dispatch
+	}
+
+	failures := 0
+	for {
+		msg, err := r.Reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return
+			}
+			failures++
+			wait := r.Backoff.Duration(failures)
+			log.Logger.Error().Err(err).Str("consumer", r.Name).Int("attempt", failures).Dur("backoff", wait).
+				Msg("Failed to read message from Kafka - backing off")
+			r.setState(kafkamonitor.StateBackingOff, err, failures)
+			if !sleepContext(ctx, wait) {
+				return
+			}
+			continue
+		}
+		if failures > 0 {
+			failures = 0
+			r.setState(kafkamonitor.StateRunning, nil, 0)
+		}
+		r.Monitor.ObserveLag(r.Reader.Config().GroupID, msg)
+
+		if r.Delay != nil {
+			if !sleepContext(ctx, r.Delay(msg)) {
+				return
+			}
+		}
+		if !dispatch(ctx, msg) {
+			return
+		}
+	}
+}
+
+// process — 처리 완료까지 재시도 후 커밋. 재시도 대기 중 ctx가 취소되면 커밋 없이 false
+// (재시작 후 같은 메시지부터 다시 읽음).
+func (r *Runner) process(ctx context.Context, msg kafka.Message) bool {
+	if !r.handleUntilDone(ctx, msg) {
+		return false
+	}
+	r.commit(msg)
+	return true
+}
+
+// handleUntilDone — Handle이 nil을 반환할 때까지 백오프 재시도. 대기 중 ctx 취소 시 false.
+func (r *Runner) handleUntilDone(ctx context.Context, msg kafka.Message) bool {
+	// 종료 신호로 ctx가 취소돼도 이미 읽은 메시지는 끝까지 처리 (대기만 shutdownContext로 중단)
+	procCtx := context.WithValue(context.WithoutCancel(ctx), shutdownKey{}, ctx)
+
+	for attempt := 1; ; attempt++ {
+		err := r.Handle(procCtx, msg)
+		if err == nil {
+			break
+		}
+		wait := r.Backoff.Duration(attempt)
+		log.Logger.Error().Err(err).Str("consumer", r.Name).Int("partition", msg.Partition).Int64("offset", msg.Offset).
+			Int("attempt", attempt).Dur("backoff", wait).Msg("Message not fully handled - retrying without commit")
+		r.setState(kafkamonitor.StateBackingOff, err, attempt)
+		if !sleepContext(ctx, wait) {
+			return false
+		}
+	}
+	r.setState(kafkamonitor.StateRunning, nil, 0)
+	return true
+}
+
+// commit — CommitInterval > 0이면 reader가 모아서 주기적으로 커밋 (Close 시 flush).
+func (r *Runner) commit(msgs ...kafka.Message) {
+	if err := r.Reader.CommitMessages(context.Background(), msgs...); err != nil {
+		for _, msg := range msgs {
+			log.Logger.Error().Err(err).Str("consumer", r.Name).Int("partition", msg.Partition).Int64("offset", msg.Offset).
+				Msg("Failed to commit offset - message may be redelivered")
+		}
+	}
+}
+
+// collectStats — Reader.Stats()를 주기적으로 Prometheus에 반영 (에러/리밸런스, 그룹 없는 reader의 lag).
+func (r *Runner) collectStats(ctx context.Context) {
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Monitor.ObserveReaderStats(r.Reader.Config().GroupID, r.Reader.Stats())
+		}
+	}
+}
+
+func (r *Runner) setState(state string, err error, failures int) {
+	if r.Monitor != nil {
+		r.Monitor.SetConsumerState(r.Name, state, err, failures)
+	}
+}
+
+type shutdownKey struct{}
+
+// shutdownContext — Runner가 Handle에 넘긴 처리 ctx에서 종료 신호 ctx를 꺼냄. 없으면 ctx 그대로.
+func shutdownContext(ctx context.Context) context.Context {
+	if shutdown, ok := ctx.Value(shutdownKey{}).(context.Context); ok {
+		return shutdown
+	}
+	return ctx
+}
+
+// sleepContext — d만큼 대기, 도중에 ctx가 취소되면 false.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
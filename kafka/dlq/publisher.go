@@ -3,29 +3,74 @@ package dlq
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"time"
+
+	"productfc/infrastructure/kafkamonitor"
+	kafkapkg "productfc/kafka"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/retry"
+	"productfc/tracing"
 
 	"github.com/segmentio/kafka-go"
 )
 
-// Message — DLQ에 넣는 래핑 페이로드 (원문 + 메타).
+// 실패 원인 분류 — 재처리 판단용 (디코드/스키마 실패는 본문 수정 없이 재처리해도 다시 실패).
+const (
+	ErrorClassProcessing = "processing"
+	ErrorClassDecode     = "decode"
+	ErrorClassSchema     = "schema"
+)
+
+const (
+	HeaderErrorClass    = "x-error-class"
+	HeaderError         = "x-error"
+	HeaderConsumerGroup = "x-consumer-group"
+	HeaderFailedAt      = "x-failed-at"
+)
+
+// Message — DLQ에 넣는 래핑 페이로드 (원문 + 출처/실패 메타).
 type Message struct {
-	OriginalTopic string          `json:"original_topic"`
-	Error         string          `json:"error"`
-	Body          json.RawMessage `json:"body"`
+	OriginalTopic     string            `json:"original_topic"`
+	OriginalPartition int               `json:"original_partition"`
+	OriginalOffset    int64             `json:"original_offset"`
+	Key               string            `json:"key,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	ConsumerGroup     string            `json:"consumer_group,omitempty"`
+	Attempts          int               `json:"attempts"`
+	ErrorClass        string            `json:"error_class"`
+	Error             string            `json:"error"`
+	FirstFailedAt     time.Time         `json:"first_failed_at"`
+	FailedAt          time.Time         `json:"failed_at"`
+	Body              json.RawMessage   `json:"body,omitempty"`
+	// RawBody — JSON이 아닌 원문 (디코드 실패 등). base64로 직렬화됨.
+	RawBody []byte `json:"raw_body,omitempty"`
+}
+
+// Failure — DLQ로 보낼 때 소비자가 채우는 실패 정보.
+type Failure struct {
+	Topic         string
+	ConsumerGroup string
+	Class         string
+	Err           error
+	Attempts      int
 }
 
-// Publisher — 실패한 원본 메시지를 DLQ 토픽으로 전송.
+// EventType — DLQ 메시지의 CloudEvents type (접두사 제외).
+const EventType = "dlq.message"
+
+// Publisher — 실패한 원본 메시지를 DLQ 토픽으로 전송 (원본 키 유지 → 주문별 순서 보존).
 type Publisher struct {
-	w *kafka.Writer
+	w       *kafka.Writer
+	events  *cloudevents.Encoder
+	monitor *kafkamonitor.Monitor
 }
 
-func NewPublisher(brokers []string, dlqTopic string) *Publisher {
+func NewPublisher(conn *kafkapkg.Connection, dlqTopic string, events *cloudevents.Encoder, mon *kafkamonitor.Monitor) *Publisher {
 	return &Publisher{
-		w: &kafka.Writer{
-			Addr:     kafka.TCP(brokers...),
-			Topic:    dlqTopic,
-			Balancer: &kafka.LeastBytes{},
-		},
+		w:       conn.Writer(dlqTopic),
+		events:  events,
+		monitor: mon,
 	}
 }
 
@@ -33,15 +78,71 @@ func (p *Publisher) Close() error {
 	return p.w.Close()
 }
 
-func (p *Publisher) Publish(ctx context.Context, originalTopic string, body []byte, processErr error) error {
+func (p *Publisher) Publish(ctx context.Context, msg kafka.Message, failure Failure) error {
+	origin := retry.OriginOf(msg, failure.Topic)
+	now := time.Now()
+
 	wrapped := Message{
-		OriginalTopic: originalTopic,
-		Error:         processErr.Error(),
-		Body:          json.RawMessage(body),
+		OriginalTopic:     origin.Topic,
+		OriginalPartition: origin.Partition,
+		OriginalOffset:    origin.Offset,
+		Key:               string(msg.Key),
+		ConsumerGroup:     failure.ConsumerGroup,
+		Attempts:          failure.Attempts,
+		ErrorClass:        failure.Class,
+		Error:             failure.Err.Error(),
+		FirstFailedAt:     retry.FirstFailure(msg),
+		FailedAt:          now,
+	}
+	if json.Valid(msg.Value) {
+		wrapped.Body = json.RawMessage(msg.Value)
+	} else {
+		wrapped.RawBody = msg.Value
+	}
+
+	headers := make([]kafka.Header, 0, len(msg.Headers)+10)
+	for _, h := range msg.Headers {
+		if retry.IsInternalHeader(h.Key) {
+			continue
+		}
+		if wrapped.Headers == nil {
+			wrapped.Headers = make(map[string]string, len(msg.Headers))
+		}
+		// 원본 CloudEvents 헤더는 재처리용으로 본문에만 보존 (DLQ 메시지 자체의 ce_* 와 섞이지 않게)
+		wrapped.Headers[h.Key] = string(h.Value)
+		if cloudevents.IsHeader(h.Key) {
+			continue
+		}
+		headers = append(headers, h)
 	}
+	headers = append(headers,
+		kafka.Header{Key: retry.HeaderOriginalTopic, Value: []byte(origin.Topic)},
+		kafka.Header{Key: retry.HeaderOriginalPartition, Value: []byte(strconv.Itoa(origin.Partition))},
+		kafka.Header{Key: retry.HeaderOriginalOffset, Value: []byte(strconv.FormatInt(origin.Offset, 10))},
+		kafka.Header{Key: retry.HeaderAttempts, Value: []byte(strconv.Itoa(wrapped.Attempts))},
+		kafka.Header{Key: retry.HeaderFirstFailure, Value: []byte(strconv.FormatInt(wrapped.FirstFailedAt.UnixMilli(), 10))},
+		kafka.Header{Key: HeaderConsumerGroup, Value: []byte(wrapped.ConsumerGroup)},
+		kafka.Header{Key: HeaderErrorClass, Value: []byte(wrapped.ErrorClass)},
+		kafka.Header{Key: HeaderError, Value: []byte(wrapped.Error)},
+		kafka.Header{Key: HeaderFailedAt, Value: []byte(strconv.FormatInt(now.UnixMilli(), 10))},
+	)
+
 	b, err := json.Marshal(wrapped)
 	if err != nil {
 		return err
 	}
-	return p.w.WriteMessages(ctx, kafka.Message{Value: b})
+	out := kafka.Message{Key: msg.Key, Value: b, Headers: headers}
+	err = p.events.Encode(p.w.Topic, &out, cloudevents.Event{
+		Type:    p.events.Type(EventType),
+		Subject: origin.Topic,
+		Time:    now,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, span := tracing.StartProducerSpan(ctx, p.w.Topic, &out)
+	err = p.w.WriteMessages(ctx, out)
+	tracing.EndProducerSpan(span, err)
+	p.monitor.RecordProduced(p.w.Topic, err)
+	return err
 }
@@ -0,0 +1,144 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"productfc/infrastructure/log"
+	"productfc/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PostgresStore — processed_events 테이블 기반 구현.
+// 재고를 바꾸는 핸들러는 CompleteInTx로 같은 트랜잭션에서 완료를 기록해,
+// 재고 변경과 멱등성 기록이 함께 커밋되거나 함께 롤백됨.
+type PostgresStore struct {
+	db      *gorm.DB
+	doneTTL time.Duration
+}
+
+func NewPostgresStore(db *gorm.DB, doneTTL time.Duration) *PostgresStore {
+	if doneTTL <= 0 {
+		doneTTL = DefaultDoneTTL
+	}
+	return &PostgresStore{db: db, doneTTL: doneTTL}
+}
+
+// claimSQL — 새 키면 삽입, lease가 만료된 processing 행이면 소유자 교체. done이거나 lease 중이면 0행.
+// 시각은 DB 시계(now()) 기준이라 인스턴스 간 시계 차이에 영향받지 않음.
+const claimSQL = `
+INSERT INTO processed_events (key, status, owner, lease_until, created_at)
+VALUES (?, ?, ?, now() + make_interval(secs => ?), now())
+ON CONFLICT (key) DO UPDATE
+SET owner = EXCLUDED.owner, lease_until = EXCLUDED.lease_until
+WHERE processed_events.status = ? AND processed_events.lease_until < now()
+RETURNING key`
+
+func (s *PostgresStore) Claim(ctx context.Context, key string, lease time.Duration) (Claim, error) {
+	claim := Claim{Key: key, Owner: uuid.NewString(), transactional: true}
+	var claimed []string
+	err := s.db.WithContext(ctx).Raw(claimSQL,
+		key, models.ProcessedEventStatusProcessing, claim.Owner, lease.Seconds(),
+		models.ProcessedEventStatusProcessing,
+	).Scan(&claimed).Error
+	if err != nil {
+		return Claim{}, fmt.Errorf("claim %s: %w", key, err)
+	}
+	if len(claimed) > 0 {
+		claim.Status = Claimed
+		return claim, nil
+	}
+
+	var row models.ProcessedEvent
+	if err := s.db.WithContext(ctx).Select("status").Where("key = ?", key).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 조회 직전에 Release로 지워짐 — 다음 시도에서 다시 점유
+			claim.Status = Busy
+			return claim, nil
+		}
+		return Claim{}, err
+	}
+	if row.Status == models.ProcessedEventStatusDone {
+		claim.Status = Done
+	} else {
+		claim.Status = Busy
+	}
+	return claim, nil
+}
+
+// Complete — 완료 기록. CompleteInTx로 이미 기록됐으면 그대로 성공.
+func (s *PostgresStore) Complete(ctx context.Context, claim Claim) error {
+	return complete(s.db.WithContext(ctx), claim, false)
+}
+
+// Release — 자기 claim이고 아직 processing이면 삭제 (완료된 기록은 남김).
+func (s *PostgresStore) Release(ctx context.Context, claim Claim) error {
+	return s.db.WithContext(ctx).
+		Where("key = ? AND owner = ? AND status = ?", claim.Key, claim.Owner, models.ProcessedEventStatusProcessing).
+		Delete(&models.ProcessedEvent{}).Error
+}
+
+// CompleteInTx — ctx의 claim을 tx 안에서 완료로 기록. 트랜잭션 저장소의 claim이 없으면 아무것도 하지 않음.
+// lease를 잃었거나 같은 claim으로 이미 완료했으면 ErrLeaseLost — 호출한 트랜잭션은 롤백해야 함.
+func CompleteInTx(ctx context.Context, tx *gorm.DB) error {
+	claim, ok := FromContext(ctx)
+	if !ok || !claim.transactional || claim.Status != Claimed {
+		return nil
+	}
+	return complete(tx, claim, true)
+}
+
+func complete(db *gorm.DB, claim Claim, strict bool) error {
+	query := db.Model(&models.ProcessedEvent{}).Where("key = ? AND owner = ?", claim.Key, claim.Owner)
+	if strict {
+		query = query.Where("status = ?", models.ProcessedEventStatusProcessing)
+	}
+	result := query.Updates(map[string]any{
+		"status":       models.ProcessedEventStatusDone,
+		"lease_until":  nil,
+		"processed_at": gorm.Expr("COALESCE(processed_at, now())"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", ErrLeaseLost, claim.Key)
+	}
+	return nil
+}
+
+// Purge — done_ttl이 지난 완료 기록 삭제 (그보다 오래된 재전송은 다시 처리될 수 있음).
+func (s *PostgresStore) Purge(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("status = ? AND processed_at < ?", models.ProcessedEventStatusDone, time.Now().Add(-s.doneTTL)).
+		Delete(&models.ProcessedEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// RunPurge — interval마다 Purge (ctx 취소 시 종료).
+func (s *PostgresStore) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		n, err := s.Purge(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Logger.Error().Err(err).Msg("Failed to purge processed events")
+			continue
+		}
+		if n > 0 {
+			log.Logger.Info().Int64("deleted", n).Msg("Purged processed events")
+		}
+	}
+}
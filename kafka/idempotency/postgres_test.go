@@ -0,0 +1,245 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"productfc/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newPostgresStore(t *testing.T) (*PostgresStore, *gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	return NewPostgresStore(db, time.Hour), db, mock
+}
+
+var (
+	claimQuery  = regexp.QuoteMeta("INSERT INTO processed_events")
+	statusQuery = regexp.QuoteMeta(`SELECT "status" FROM "processed_events" WHERE key = $1`)
+	updateQuery = regexp.QuoteMeta(`UPDATE "processed_events" SET`)
+	deleteQuery = regexp.QuoteMeta(`DELETE FROM "processed_events" WHERE key = $1 AND owner = $2 AND status = $3`)
+)
+
+func TestPostgresStoreClaim(t *testing.T) {
+	tests := []struct {
+		name string
+		// claimed — INSERT ... ON CONFLICT가 행을 반환함 (새 키이거나 lease가 만료된 processing 행을 가져옴)
+		claimed bool
+		status  string // claimed가 아닐 때 기존 행 상태 ("" = 조회 직전에 삭제됨)
+		want    Status
+	}{
+		{name: "new key", claimed: true, want: Claimed},
+		{name: "completed is never reclaimed", status: models.ProcessedEventStatusDone, want: Done},
+		{name: "active lease", status: models.ProcessedEventStatusProcessing, want: Busy},
+		{name: "released before lookup", want: Busy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _, mock := newPostgresStore(t)
+			rows := sqlmock.NewRows([]string{"key"})
+			if tt.claimed {
+				rows.AddRow("group:evt-1")
+			}
+			mock.ExpectQuery(claimQuery).
+				WithArgs("group:evt-1", models.ProcessedEventStatusProcessing, sqlmock.AnyArg(), 30.0, models.ProcessedEventStatusProcessing).
+				WillReturnRows(rows)
+			if !tt.claimed {
+				status := sqlmock.NewRows([]string{"status"})
+				if tt.status != "" {
+					status.AddRow(tt.status)
+				}
+				mock.ExpectQuery(statusQuery).WillReturnRows(status)
+			}
+
+			claim, err := store.Claim(context.Background(), "group:evt-1", 30*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claim.Status != tt.want {
+				t.Fatalf("status = %v, want %v", claim.Status, tt.want)
+			}
+			if claim.Owner == "" || !claim.transactional {
+				t.Fatalf("claim = %+v, want owner and transactional", claim)
+			}
+		})
+	}
+}
+
+// ownerArg — claimSQL에 넘어간 owner 토큰을 기록.
+type ownerArg struct{ got *string }
+
+func (a ownerArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	*a.got = s
+	return ok && s != ""
+}
+
+// lease가 만료된 행은 새 owner로 다시 점유되고, 이전 owner의 늦은 완료는 재고 트랜잭션과 함께 롤백됨.
+func TestPostgresStoreReclaimExpiredLease(t *testing.T) {
+	store, db, mock := newPostgresStore(t)
+	ctx := context.Background()
+
+	var firstOwner, secondOwner string
+	mock.ExpectQuery(claimQuery).
+		WithArgs("group:evt-1", models.ProcessedEventStatusProcessing, ownerArg{&firstOwner}, 30.0, models.ProcessedEventStatusProcessing).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("group:evt-1"))
+	first, err := store.Claim(ctx, "group:evt-1", 30*time.Second)
+	if err != nil || first.Status != Claimed {
+		t.Fatalf("first claim = %+v, %v", first, err)
+	}
+
+	// ON CONFLICT ... WHERE lease_until < now() 로 만료된 processing 행을 가져옴
+	mock.ExpectQuery(claimQuery).
+		WithArgs("group:evt-1", models.ProcessedEventStatusProcessing, ownerArg{&secondOwner}, 30.0, models.ProcessedEventStatusProcessing).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("group:evt-1"))
+	second, err := store.Claim(ctx, "group:evt-1", 30*time.Second)
+	if err != nil || second.Status != Claimed {
+		t.Fatalf("reclaim = %+v, %v", second, err)
+	}
+	if first.Owner != firstOwner || second.Owner != secondOwner || firstOwner == secondOwner {
+		t.Fatalf("owners = %q/%q (sql %q/%q), want distinct owners written to the row", first.Owner, second.Owner, firstOwner, secondOwner)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateQuery).
+		WithArgs(nil, models.ProcessedEventStatusDone, "group:evt-1", first.Owner, models.ProcessedEventStatusProcessing).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Product{}).Where("id = ?", 1).Update("stock", gorm.Expr("stock - ?", 2)).Error; err != nil {
+			return err
+		}
+		return CompleteInTx(WithClaim(ctx, first), tx)
+	})
+	if !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("stale owner CompleteInTx error = %v, want ErrLeaseLost", err)
+	}
+}
+
+func TestPostgresStoreRelease(t *testing.T) {
+	store, _, mock := newPostgresStore(t)
+	claim := Claim{Key: "group:evt-1", Owner: "owner-1", Status: Claimed, transactional: true}
+
+	// 완료된 행은 status 조건으로 남고, 자기 processing 행만 삭제
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteQuery).
+		WithArgs(claim.Key, claim.Owner, models.ProcessedEventStatusProcessing).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := store.Release(context.Background(), claim); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompleteInTx(t *testing.T) {
+	stockUpdate := regexp.QuoteMeta(`UPDATE "products" SET "stock"=stock - $1`)
+	tests := []struct {
+		name       string
+		claim      *Claim
+		completed  int64 // 완료 UPDATE가 바꾼 행 수
+		wantErr    error
+		wantCommit bool
+	}{
+		{
+			name:       "completes with stock change",
+			claim:      &Claim{Key: "group:evt-1", Owner: "owner-1", Status: Claimed, transactional: true},
+			completed:  1,
+			wantCommit: true,
+		},
+		{
+			name:    "lease lost rolls back stock change",
+			claim:   &Claim{Key: "group:evt-1", Owner: "owner-1", Status: Claimed, transactional: true},
+			wantErr: ErrLeaseLost,
+		},
+		{
+			name:       "no claim in context",
+			wantCommit: true,
+		},
+		{
+			name:       "non-transactional store claim",
+			claim:      &Claim{Key: "group:evt-1", Owner: "owner-1", Status: Claimed},
+			wantCommit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, db, mock := newPostgresStore(t)
+			ctx := context.Background()
+			if tt.claim != nil {
+				ctx = WithClaim(ctx, *tt.claim)
+			}
+			writesClaim := tt.claim != nil && tt.claim.transactional
+
+			mock.ExpectBegin()
+			mock.ExpectExec(stockUpdate).WillReturnResult(sqlmock.NewResult(0, 1))
+			if writesClaim {
+				mock.ExpectExec(updateQuery).
+					WithArgs(nil, models.ProcessedEventStatusDone, tt.claim.Key, tt.claim.Owner, models.ProcessedEventStatusProcessing).
+					WillReturnResult(sqlmock.NewResult(0, tt.completed))
+			}
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Model(&models.Product{}).Where("id = ?", 1).
+					Update("stock", gorm.Expr("stock - ?", 2)).Error; err != nil {
+					return err
+				}
+				return CompleteInTx(ctx, tx)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostgresStoreCompleteAfterCompleteInTx(t *testing.T) {
+	store, _, mock := newPostgresStore(t)
+	claim := Claim{Key: "group:evt-1", Owner: "owner-1", Status: Claimed, transactional: true}
+
+	// Complete는 status 조건 없이 자기 행을 done으로 (이미 done이어도 성공)
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).
+		WithArgs(nil, models.ProcessedEventStatusDone, claim.Key, claim.Owner).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	if err := store.Complete(context.Background(), claim); err != nil {
+		t.Fatal(err)
+	}
+
+	// 다른 소유자가 가져간 뒤면 ErrLeaseLost
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	if err := store.Complete(context.Background(), claim); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("error = %v, want ErrLeaseLost", err)
+	}
+}
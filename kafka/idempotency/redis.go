@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"productfc/infrastructure/cache"
+
+	"github.com/google/uuid"
+)
+
+// RedisStore — 캐시 기반 구현. lease는 SET NX PX, 완료는 done 키.
+// 재고 변경과 원자적이지 않으므로 완료 기록 전 장애 시 재처리될 수 있음 (PostgresStore 권장).
+type RedisStore struct {
+	cache   cache.Cache
+	doneTTL time.Duration
+}
+
+func NewRedisStore(c cache.Cache, doneTTL time.Duration) *RedisStore {
+	if doneTTL <= 0 {
+		doneTTL = DefaultDoneTTL
+	}
+	return &RedisStore{cache: c, doneTTL: doneTTL}
+}
+
+func doneKey(key string) string {
+	return "kafka:done:" + key
+}
+
+func leaseKey(key string) string {
+	return "kafka:lease:" + key
+}
+
+func (s *RedisStore) Claim(ctx context.Context, key string, lease time.Duration) (Claim, error) {
+	claim := Claim{Key: key, Owner: uuid.NewString()}
+	done, err := s.cache.Exists(ctx, doneKey(key))
+	if err != nil {
+		return Claim{}, err
+	}
+	if done {
+		claim.Status = Done
+		return claim, nil
+	}
+	ok, err := s.cache.SetNX(ctx, leaseKey(key), []byte(claim.Owner), lease)
+	if err != nil {
+		return Claim{}, err
+	}
+	if !ok {
+		claim.Status = Busy
+		return claim, nil
+	}
+
+	// done 확인과 점유 사이에 다른 인스턴스가 완료하고 lease를 지웠을 수 있음
+	done, err = s.cache.Exists(ctx, doneKey(key))
+	if err != nil || done {
+		_, _ = s.cache.DelIfEqual(ctx, leaseKey(key), claim.Owner)
+		if err != nil {
+			return Claim{}, err
+		}
+		claim.Status = Done
+		return claim, nil
+	}
+	claim.Status = Claimed
+	return claim, nil
+}
+
+func (s *RedisStore) Complete(ctx context.Context, claim Claim) error {
+	if err := s.cache.Set(ctx, doneKey(claim.Key), []byte(claim.Owner), s.doneTTL); err != nil {
+		return err
+	}
+	_, err := s.cache.DelIfEqual(ctx, leaseKey(claim.Key), claim.Owner)
+	return err
+}
+
+func (s *RedisStore) Release(ctx context.Context, claim Claim) error {
+	_, err := s.cache.DelIfEqual(ctx, leaseKey(claim.Key), claim.Owner)
+	return err
+}
@@ -0,0 +1,135 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"productfc/infrastructure/cache"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStore(cache.NewRedisCache(client), time.Hour), mr
+}
+
+func TestRedisStoreClaim(t *testing.T) {
+	const lease = 30 * time.Second
+	ctx := context.Background()
+
+	type step struct {
+		action  string // claim | complete | release | expire
+		claim   int    // complete/release 대상 (claims 인덱스)
+		advance time.Duration
+		want    Status
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "second claim is busy during lease",
+			steps: []step{
+				{action: "claim", want: Claimed},
+				{action: "claim", want: Busy},
+			},
+		},
+		{
+			name: "expired lease is reclaimed",
+			steps: []step{
+				{action: "claim", want: Claimed},
+				{action: "expire", advance: lease + time.Second},
+				{action: "claim", want: Claimed},
+				// 늦게 끝난 이전 소유자의 Release는 새 lease를 지우지 않음
+				{action: "release", claim: 0},
+				{action: "claim", want: Busy},
+			},
+		},
+		{
+			name: "completed event is never reclaimed",
+			steps: []step{
+				{action: "claim", want: Claimed},
+				{action: "complete", claim: 0},
+				{action: "claim", want: Done},
+				{action: "expire", advance: lease + time.Second},
+				{action: "claim", want: Done},
+			},
+		},
+		{
+			name: "release after failure allows retry",
+			steps: []step{
+				{action: "claim", want: Claimed},
+				{action: "release", claim: 0},
+				{action: "claim", want: Claimed},
+				{action: "complete", claim: 1},
+				{action: "claim", want: Done},
+			},
+		},
+		{
+			name: "done record expires after done ttl",
+			steps: []step{
+				{action: "claim", want: Claimed},
+				{action: "complete", claim: 0},
+				{action: "expire", advance: time.Hour + time.Second},
+				{action: "claim", want: Claimed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mr := newRedisStore(t)
+			var claims []Claim
+			for i, s := range tt.steps {
+				switch s.action {
+				case "claim":
+					claim, err := store.Claim(ctx, "group:evt-1", lease)
+					if err != nil {
+						t.Fatalf("step %d: %v", i, err)
+					}
+					if claim.Status != s.want {
+						t.Fatalf("step %d: status = %v, want %v", i, claim.Status, s.want)
+					}
+					if claim.Status == Claimed {
+						claims = append(claims, claim)
+					}
+				case "complete":
+					if err := store.Complete(ctx, claims[s.claim]); err != nil {
+						t.Fatalf("step %d: %v", i, err)
+					}
+				case "release":
+					if err := store.Release(ctx, claims[s.claim]); err != nil {
+						t.Fatalf("step %d: %v", i, err)
+					}
+				case "expire":
+					mr.FastForward(s.advance)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisStoreClaimOwnersDiffer(t *testing.T) {
+	store, mr := newRedisStore(t)
+	ctx := context.Background()
+	first, err := store.Claim(ctx, "group:evt-1", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr.FastForward(2 * time.Second)
+	second, err := store.Claim(ctx, "group:evt-1", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Owner == second.Owner {
+		t.Fatalf("reclaimed owner = %q, want a new owner", second.Owner)
+	}
+	if got, _ := mr.Get(leaseKey("group:evt-1")); got != second.Owner {
+		t.Fatalf("lease owner = %q, want %q", got, second.Owner)
+	}
+}
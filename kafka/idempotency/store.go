@@ -2,37 +2,82 @@ package idempotency
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"productfc/config"
+	"productfc/infrastructure/cache"
+
+	"gorm.io/gorm"
 )
 
-const doneTTL = 7 * 24 * time.Hour
+const (
+	StorePostgres = "postgres"
+	StoreRedis    = "redis"
 
-// Store — 동일 order_id + 토픽에 대한 중복 처리 방지 (at-least-once 소비 시).
-type Store struct {
-	rdb *redis.Client
-}
+	DefaultLease   = 30 * time.Second
+	DefaultDoneTTL = 7 * 24 * time.Hour
+)
+
+// ErrLeaseLost — lease가 만료돼 다른 인스턴스가 가져갔거나 이미 완료된 claim으로 완료 기록을 시도함.
+var ErrLeaseLost = errors.New("idempotency lease lost")
+
+// Status — Claim 결과.
+type Status int
+
+const (
+	// Claimed — 처리 권한 획득 (lease 동안 다른 인스턴스는 Busy).
+	Claimed Status = iota
+	// Done — 이미 처리 완료된 이벤트 (중복).
+	Done
+	// Busy — 다른 인스턴스가 처리 중 (커밋하지 말고 나중에 다시).
+	Busy
+)
 
-func NewStore(rdb *redis.Client) *Store {
-	return &Store{rdb: rdb}
+// Claim — 이벤트 처리 권한. Owner는 claim마다 새로 발급하는 토큰으로,
+// lease가 만료된 뒤 늦게 끝난 처리가 남의 claim을 완료/해제하지 못하게 함.
+type Claim struct {
+	Key    string
+	Owner  string
+	Status Status
+	// transactional — 재고 변경 트랜잭션 안에서 완료를 기록하는 저장소 (CompleteInTx)
+	transactional bool
 }
 
-func doneKey(topic string, orderID int64) string {
-	return fmt.Sprintf("kafka:done:%s:%d", topic, orderID)
+// Store — 이벤트 단위 멱등성 저장소.
+// Claim으로 lease를 잡고, 성공/거절 시 Complete, 실패 시 Release (다른 인스턴스나 재시도가 다시 처리).
+type Store interface {
+	Claim(ctx context.Context, key string, lease time.Duration) (Claim, error)
+	Complete(ctx context.Context, claim Claim) error
+	Release(ctx context.Context, claim Claim) error
 }
 
-// AlreadyProcessed — 성공 처리 완료 키가 있으면 true (중복 메시지).
-func (s *Store) AlreadyProcessed(ctx context.Context, topic string, orderID int64) (bool, error) {
-	n, err := s.rdb.Exists(ctx, doneKey(topic, orderID)).Result()
-	if err != nil {
-		return false, err
+// NewStore — kafka.idempotency.store로 구현 선택 (비어 있으면 postgres).
+func NewStore(cfg config.KafkaIdempotencyConfig, c cache.Cache, db *gorm.DB) (Store, error) {
+	switch cfg.Store {
+	case "", StorePostgres:
+		return NewPostgresStore(db, cfg.DoneTTL), nil
+	case StoreRedis:
+		return NewRedisStore(c, cfg.DoneTTL), nil
 	}
-	return n > 0, nil
+	return nil, fmt.Errorf("unsupported idempotency store %q (postgres | redis)", cfg.Store)
+}
+
+// Key — 컨슈머 그룹 + 이벤트 ID 단위 멱등성 키 (같은 주문의 다른 이벤트는 별개로 처리).
+func Key(groupID, eventID string) string {
+	return fmt.Sprintf("%s:%s", groupID, eventID)
+}
+
+type claimKey struct{}
+
+// WithClaim — 핸들러 ctx에 claim을 실어 저장소 트랜잭션에서 완료를 함께 기록하게 함.
+func WithClaim(ctx context.Context, claim Claim) context.Context {
+	return context.WithValue(ctx, claimKey{}, claim)
 }
 
-// MarkProcessed — 모든 비즈니스 처리 성공 후 호출.
-func (s *Store) MarkProcessed(ctx context.Context, topic string, orderID int64) error {
-	return s.rdb.Set(ctx, doneKey(topic, orderID), "1", doneTTL).Err()
+// FromContext — ctx의 claim (없으면 false).
+func FromContext(ctx context.Context) (Claim, bool) {
+	claim, ok := ctx.Value(claimKey{}).(Claim)
+	return claim, ok
 }
@@ -2,47 +2,110 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"productfc/infrastructure/kafkamonitor"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/codec"
+	"productfc/kafka/schema"
 	"productfc/models"
+	"productfc/tracing"
+	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 )
 
 type Producer struct {
-	writer *kafka.Writer
+	writer  *kafka.Writer
+	topics  Topics
+	codecs  *codec.Selector
+	events  *cloudevents.Encoder
+	monitor *kafkamonitor.Monitor
 }
 
-func NewProducer(brokers []string) *Producer {
+// NewProducer — codecs가 nil이면 JSON, events가 nil이면 CloudEvents 없이 본문만 발행 (CLI 등).
+func NewProducer(conn *Connection, topics Topics, codecs *codec.Selector, events *cloudevents.Encoder, mon *kafkamonitor.Monitor) *Producer {
 	return &Producer{
-		writer: &kafka.Writer{
-			Addr:     kafka.TCP(brokers...),
-			Balancer: &kafka.Hash{},
-		},
+		writer:  conn.Writer(""),
+		topics:  topics,
+		codecs:  codecs,
+		events:  events,
+		monitor: mon,
 	}
 }
 
+func (p *Producer) Topics() Topics {
+	return p.topics
+}
+
 func (p *Producer) Close() error {
 	return p.writer.Close()
 }
 
 func (p *Producer) PublishStockReserved(ctx context.Context, event models.StockReservationEvent) error {
-	return p.publishStockReservation(ctx, TopicStockReserved, event)
+	return p.publishStockReservation(ctx, p.topics.StockReserved, TopicStockReserved, event)
 }
 
 func (p *Producer) PublishStockRejected(ctx context.Context, event models.StockReservationEvent) error {
-	return p.publishStockReservation(ctx, TopicStockRejected, event)
+	return p.publishStockReservation(ctx, p.topics.StockRejected, TopicStockRejected, event)
+}
+
+func (p *Producer) publishStockReservation(ctx context.Context, topic, eventType string, event models.StockReservationEvent) error {
+	return p.publishEvent(ctx, topic, []byte(fmt.Sprintf("order-%d", event.OrderID)), schema.StockReservation, cloudevents.Event{
+		Type:    p.events.Type(eventType),
+		Subject: strconv.FormatInt(event.OrderID, 10),
+		Time:    event.EventTime,
+	}, event)
 }
 
-func (p *Producer) publishStockReservation(ctx context.Context, topic string, event models.StockReservationEvent) error {
-	payload, err := json.Marshal(event)
+// publishEvent — 토픽 codec(json/protobuf/avro)으로 직렬화(스키마 검증 포함)하고 content-type 헤더를 붙인 뒤,
+// 토픽 설정에 따라 CloudEvents(binary/structured)로 인코딩해 발행.
+// ce.Type은 기본 토픽명 기반 (토픽 이름을 재정의해도 CloudEvents type은 유지).
+func (p *Producer) publishEvent(ctx context.Context, topic string, key []byte, schemaName string, ce cloudevents.Event, event any) error {
+	c := p.codecs.For(topic)
+	payload, err := c.Marshal(schemaName, event)
 	if err != nil {
 		return err
 	}
+	msg := kafka.Message{
+		Topic:   topic,
+		Key:     key,
+		Value:   payload,
+		Headers: []kafka.Header{{Key: codec.HeaderContentType, Value: []byte(c.ContentType())}},
+	}
+	ce.DataContentType = c.ContentType()
+	if c == codec.JSON {
+		ce.DataSchema = schema.Default.URL(schemaName)
+	}
+	// 이벤트마다 ID를 붙임 — CloudEvents면 ce id, 레거시(none)면 x-event-id 헤더
+	if ce.ID == "" {
+		ce.ID = uuid.NewString()
+	}
+	if p.events.Mode(topic) == cloudevents.ModeNone {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: HeaderEventID, Value: []byte(ce.ID)})
+	}
+	if err := p.events.Encode(topic, &msg, ce); err != nil {
+		return err
+	}
+	return p.write(ctx, msg)
+}
 
-	return p.writer.WriteMessages(ctx, kafka.Message{
-		Topic: topic,
-		Key:   []byte(fmt.Sprintf("order-%d", event.OrderID)),
-		Value: payload,
+// PublishRaw — 이미 직렬화된 메시지를 임의 토픽으로 발행 (DLQ 재처리 등).
+func (p *Producer) PublishRaw(ctx context.Context, topic string, key, value []byte, headers ...kafka.Header) error {
+	return p.write(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     key,
+		Value:   value,
+		Headers: headers,
 	})
 }
+
+// write — producer span 생성 + 트레이스 컨텍스트 헤더 주입 후 발행.
+func (p *Producer) write(ctx context.Context, msg kafka.Message) error {
+	msg.Headers = append([]kafka.Header(nil), msg.Headers...)
+	ctx, span := tracing.StartProducerSpan(ctx, msg.Topic, &msg)
+	err := p.writer.WriteMessages(ctx, msg)
+	tracing.EndProducerSpan(span, err)
+	p.monitor.RecordProduced(msg.Topic, err)
+	return err
+}
@@ -0,0 +1,192 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"productfc/infrastructure/kafkamonitor"
+	kafkapkg "productfc/kafka"
+	"productfc/tracing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	HeaderRetryCount    = "x-retry-count"
+	HeaderNotBefore     = "x-not-before"
+	HeaderOriginalTopic = "x-original-topic"
+	HeaderLastError     = "x-last-error"
+
+	// 원본 메시지 출처 — 첫 재발행 때 기록하고 이후 tier/DLQ까지 유지
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderAttempts          = "x-attempts"
+	HeaderFirstFailure      = "x-first-failure"
+)
+
+// Tier — 지연 재처리 단계 (예: stock.updated.retry.1m).
+type Tier struct {
+	Delay time.Duration
+	Topic string
+}
+
+// TopicName — "stock.updated" + 1m → "stock.updated.retry.1m".
+func TopicName(topic string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", topic, formatDelay(delay))
+}
+
+func formatDelay(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
+
+// Publisher — 처리 실패 메시지를 다음 재시도 tier 토픽으로 재발행 (재시도 횟수 + not-before 헤더).
+// 마지막 tier까지 실패하면 호출자가 DLQ로 보냄.
+type Publisher struct {
+	w       *kafka.Writer
+	topic   string
+	tiers   []Tier
+	monitor *kafkamonitor.Monitor
+}
+
+func NewPublisher(conn *kafkapkg.Connection, topic string, delays []time.Duration, mon *kafkamonitor.Monitor) *Publisher {
+	tiers := make([]Tier, 0, len(delays))
+	for _, delay := range delays {
+		tiers = append(tiers, Tier{Delay: delay, Topic: TopicName(topic, delay)})
+	}
+	return &Publisher{
+		w:       conn.Writer(""),
+		topic:   topic,
+		tiers:   tiers,
+		monitor: mon,
+	}
+}
+
+func (p *Publisher) Tiers() []Tier {
+	return p.tiers
+}
+
+func (p *Publisher) Close() error {
+	return p.w.Close()
+}
+
+// Publish — msg의 재시도 횟수 기준 다음 tier로 재발행. 남은 tier가 없으면 false (DLQ 대상).
+// attempts는 지금까지의 누적 처리 시도 횟수.
+func (p *Publisher) Publish(ctx context.Context, msg kafka.Message, processErr error, attempts int) (bool, error) {
+	count := Count(msg)
+	if count >= len(p.tiers) {
+		return false, nil
+	}
+	tier := p.tiers[count]
+	origin := OriginOf(msg, p.topic)
+
+	headers := make([]kafka.Header, 0, len(msg.Headers)+8)
+	for _, h := range msg.Headers {
+		if IsInternalHeader(h.Key) {
+			continue
+		}
+		headers = append(headers, h)
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderRetryCount, Value: []byte(strconv.Itoa(count + 1))},
+		kafka.Header{Key: HeaderNotBefore, Value: []byte(strconv.FormatInt(time.Now().Add(tier.Delay).UnixMilli(), 10))},
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(origin.Topic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(origin.Partition))},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(origin.Offset, 10))},
+		kafka.Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderFirstFailure, Value: []byte(strconv.FormatInt(FirstFailure(msg).UnixMilli(), 10))},
+		kafka.Header{Key: HeaderLastError, Value: []byte(processErr.Error())},
+	)
+
+	out := kafka.Message{
+		Topic:   tier.Topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+	ctx, span := tracing.StartProducerSpan(ctx, tier.Topic, &out)
+	err := p.w.WriteMessages(ctx, out)
+	tracing.EndProducerSpan(span, err)
+	p.monitor.RecordProduced(tier.Topic, err)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Count — 지금까지 거친 재시도 tier 수 (원본 토픽 메시지는 0).
+func Count(msg kafka.Message) int {
+	n, _ := strconv.Atoi(header(msg, HeaderRetryCount))
+	return n
+}
+
+// Delay — not-before까지 남은 시간 (없거나 지났으면 0).
+func Delay(msg kafka.Message) time.Duration {
+	ms, err := strconv.ParseInt(header(msg, HeaderNotBefore), 10, 64)
+	if err != nil {
+		return 0
+	}
+	if wait := time.Until(time.UnixMilli(ms)); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Origin — 재시도 tier를 거치기 전 원본 메시지 위치.
+type Origin struct {
+	Topic     string
+	Partition int
+	Offset    int64
+}
+
+// OriginOf — 재시도 헤더가 있으면 헤더 기준, 없으면 msg 자신이 원본.
+func OriginOf(msg kafka.Message, topic string) Origin {
+	if header(msg, HeaderOriginalTopic) == "" {
+		return Origin{Topic: topic, Partition: msg.Partition, Offset: msg.Offset}
+	}
+	partition, _ := strconv.Atoi(header(msg, HeaderOriginalPartition))
+	offset, _ := strconv.ParseInt(header(msg, HeaderOriginalOffset), 10, 64)
+	return Origin{Topic: header(msg, HeaderOriginalTopic), Partition: partition, Offset: offset}
+}
+
+// Attempts — 이전 tier까지의 누적 처리 시도 횟수.
+func Attempts(msg kafka.Message) int {
+	n, _ := strconv.Atoi(header(msg, HeaderAttempts))
+	return n
+}
+
+// FirstFailure — 최초 실패 시각 (재시도 전이면 지금).
+func FirstFailure(msg kafka.Message) time.Time {
+	ms, err := strconv.ParseInt(header(msg, HeaderFirstFailure), 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+	return time.UnixMilli(ms)
+}
+
+// IsInternalHeader — 재시도 단계에서 붙인 헤더 (재발행/DLQ 시 새 값으로 교체).
+func IsInternalHeader(key string) bool {
+	switch key {
+	case HeaderRetryCount, HeaderNotBefore, HeaderOriginalTopic, HeaderLastError,
+		HeaderOriginalPartition, HeaderOriginalOffset, HeaderAttempts, HeaderFirstFailure:
+		return true
+	}
+	return false
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
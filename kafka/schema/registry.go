@@ -0,0 +1,289 @@
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// 이벤트 스키마 이름. 토픽과 1:1이 아닌 경우가 있음 (stock.reserved/stock.rejected → stock.reservation,
+// stock.low/out/back_in_stock → stock.level, stock.backordered/backorder_allocated → stock.backorder,
+// product.* → product.changed).
+const (
+	OrderCreated     = "order.created"
+	StockUpdated     = "stock.updated"
+	StockRollback    = "stock.rollback"
+	StockReservation = "stock.reservation"
+	StockChanged     = "stock.changed"
+	StockLevel       = "stock.level"
+	StockBackorder   = "stock.backorder"
+	ProductChanged   = "product.changed"
+	CategoryChanged  = "category.changed"
+)
+
+const baseURL = "https://productfc/schemas/"
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+var schemaFile = regexp.MustCompile(`^(.+)\.v(\d+)\.json$`)
+
+// ErrUnsupportedVersion — 등록되지 않은 schema_version (보통 이 서비스보다 새 버전).
+var ErrUnsupportedVersion = errors.New("unsupported schema_version")
+
+// Error — 스키마 검증/버전 실패. 본문을 고치지 않으면 재처리해도 같은 결과.
+type Error struct {
+	Subject string
+	Version int
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("schema %s v%d: %v", e.Subject, e.Version, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Upcaster — 문서를 한 버전 위(from → from+1)로 변환. schema_version은 Registry가 갱신.
+type Upcaster func(doc map[string]any) error
+
+type subject struct {
+	versions  map[int]*jsonschema.Schema
+	current   int
+	upcasters map[int]Upcaster
+}
+
+// Registry — 이벤트별 버전 스키마와 업캐스터.
+type Registry struct {
+	subjects map[string]*subject
+}
+
+// Default — 내장 스키마로 만든 기본 레지스트리 (프로듀서/컨슈머 공용).
+var Default = MustLoad()
+
+// upcasters — 이전 버전 → 다음 버전 변환. 새 버전 스키마를 추가하면 여기에 직전 버전 변환을 등록.
+var upcasters = map[string]map[int]Upcaster{
+	// v0: 주문 서비스가 schema_version 없이 보내던 페이로드 — 필드 구조는 v1과 같음
+	OrderCreated:  {0: noop},
+	StockUpdated:  {0: noop},
+	StockRollback: {0: noop},
+	// v2: reason에 adjusted/stocktake/received 추가 — v1 값은 모두 v2에서도 유효
+	StockChanged: {1: noop},
+}
+
+func noop(map[string]any) error { return nil }
+
+// Load — 내장 스키마(schemas/<subject>.v<N>.json)를 모두 컴파일.
+func Load() (*Registry, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.AssertFormat = true
+
+	entries, err := fs.ReadDir(schemaFS, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	type versioned struct {
+		subject string
+		version int
+		url     string
+	}
+	var files []versioned
+	for _, entry := range entries {
+		b, err := schemaFS.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		url := baseURL + entry.Name()
+		if err := compiler.AddResource(url, bytes.NewReader(b)); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", entry.Name(), err)
+		}
+		if m := schemaFile.FindStringSubmatch(entry.Name()); m != nil {
+			version, _ := strconv.Atoi(m[2])
+			files = append(files, versioned{subject: m[1], version: version, url: url})
+		}
+	}
+
+	r := &Registry{subjects: make(map[string]*subject)}
+	for _, f := range files {
+		compiled, err := compiler.Compile(f.url)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s v%d: %w", f.subject, f.version, err)
+		}
+		s, ok := r.subjects[f.subject]
+		if !ok {
+			s = &subject{versions: make(map[int]*jsonschema.Schema), upcasters: upcasters[f.subject]}
+			r.subjects[f.subject] = s
+		}
+		s.versions[f.version] = compiled
+		s.current = max(s.current, f.version)
+	}
+
+	for name, s := range r.subjects {
+		for v := range s.versions {
+			if v < s.current && s.upcasters[v] == nil {
+				return nil, fmt.Errorf("schema %s: no upcaster from v%d", name, v)
+			}
+		}
+	}
+	return r, nil
+}
+
+func MustLoad() *Registry {
+	r, err := Load()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Subjects — 등록된 스키마 이름과 현재 버전.
+func (r *Registry) Subjects() map[string]int {
+	out := make(map[string]int, len(r.subjects))
+	for name, s := range r.subjects {
+		out[name] = s.current
+	}
+	return out
+}
+
+// Current — 이벤트의 현재(발행) 버전.
+func (r *Registry) Current(name string) int {
+	if s, ok := r.subjects[name]; ok {
+		return s.current
+	}
+	return 0
+}
+
+// URL — 현재 버전 스키마의 식별자 (CloudEvents dataschema).
+func (r *Registry) URL(name string) string {
+	return fmt.Sprintf("%s%s.v%d.json", baseURL, name, r.Current(name))
+}
+
+// Decode — schema_version(없으면 0)의 스키마로 검증하고 현재 버전까지 업캐스트한 뒤 v에 채움.
+func (r *Registry) Decode(name string, data []byte, v any) error {
+	s, err := r.subject(name)
+	if err != nil {
+		return err
+	}
+	doc, err := parse(data)
+	if err != nil {
+		return err
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return &Error{Subject: name, Err: errors.New("payload is not a JSON object")}
+	}
+
+	version, err := schemaVersion(obj)
+	if err != nil {
+		return &Error{Subject: name, Err: err}
+	}
+	compiled, ok := s.versions[version]
+	if !ok {
+		return &Error{Subject: name, Version: version, Err: fmt.Errorf("%w (current %d)", ErrUnsupportedVersion, s.current)}
+	}
+	if err := compiled.Validate(obj); err != nil {
+		return &Error{Subject: name, Version: version, Err: err}
+	}
+
+	if version == s.current {
+		return json.Unmarshal(data, v)
+	}
+	for ; version < s.current; version++ {
+		if err := s.upcasters[version](obj); err != nil {
+			return &Error{Subject: name, Version: version, Err: fmt.Errorf("upcast to v%d: %w", version+1, err)}
+		}
+		obj["schema_version"] = json.Number(strconv.Itoa(version + 1))
+	}
+	// 업캐스터 결과도 현재 스키마를 만족해야 함
+	if err := s.versions[s.current].Validate(obj); err != nil {
+		return &Error{Subject: name, Version: s.current, Err: fmt.Errorf("upcast result: %w", err)}
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Encode — 직렬화 후 현재 버전 스키마로 검증 (잘못된 이벤트는 발행하지 않음).
+func (r *Registry) Encode(name string, v any) ([]byte, error) {
+	s, err := r.subject(name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.versions[s.current].Validate(doc); err != nil {
+		return nil, &Error{Subject: name, Version: s.current, Err: err}
+	}
+	return b, nil
+}
+
+// Validate — 이벤트 값을 현재 버전 스키마로 검증 (JSON 외 형식으로 주고받는 이벤트용).
+func (r *Registry) Validate(name string, v any) error {
+	_, err := r.Encode(name, v)
+	return err
+}
+
+// Upcast — JSON 외 형식(protobuf/avro)으로 받은 이벤트 값을 Decode와 같은 규칙으로 처리.
+// 값의 schema_version 스키마로 검증하고 현재 버전까지 업캐스트해 v를 다시 채움 (이전 버전 프로듀서 호환).
+func (r *Registry) Upcast(name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// 업캐스트 결과에 없는(omitempty) 필드가 이전 값으로 남지 않도록 비우고 채움
+	reflect.ValueOf(v).Elem().SetZero()
+	return r.Decode(name, b, v)
+}
+
+func (r *Registry) subject(name string) (*subject, error) {
+	s, ok := r.subjects[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event schema %q", name)
+	}
+	return s, nil
+}
+
+// parse — 큰 정수(주문 ID 등)가 float64로 뭉개지지 않도록 json.Number로 파싱.
+func parse(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func schemaVersion(doc map[string]any) (int, error) {
+	raw, ok := doc["schema_version"]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("schema_version must be an integer, got %T", raw)
+	}
+	version, err := strconv.Atoi(n.String())
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid schema_version %q", n)
+	}
+	return version, nil
+}
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"productfc/models"
+)
+
+func TestVerifyGoldenSamples(t *testing.T) {
+	r, err := Load()
+	if err != nil {
+		t.Fatalf("load registry: %v", err)
+	}
+	results, err := r.Verify()
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	verified := make(map[string]bool)
+	for _, result := range results {
+		verified[result.Subject] = true
+		if result.Err != nil {
+			t.Errorf("%s v%d: %v", result.Subject, result.Version, result.Err)
+		}
+	}
+	for name := range r.Subjects() {
+		if !verified[name] {
+			t.Errorf("%s: no golden sample verified", name)
+		}
+	}
+}
+
+func TestDecodeUpcastRoundTrip(t *testing.T) {
+	r := MustLoad()
+	tests := []struct {
+		name    string
+		subject string
+		legacy  string
+		event   func() any
+	}{
+		{
+			name:    "stock.updated v0 without schema_version",
+			subject: StockUpdated,
+			legacy:  `{"order_id":1001,"user_id":42,"products":[{"product_id":1,"quantity":2}],"event_time":"2025-01-02T03:04:05Z"}`,
+			event:   func() any { return &models.ProductStockUpdatedEvent{} },
+		},
+		{
+			name:    "stock.changed v1 to v2",
+			subject: StockChanged,
+			legacy:  `{"schema_version":1,"product_id":1,"category_id":3,"old_stock":10,"new_stock":8,"delta":-2,"reason":"reserved","event_time":"2025-01-02T03:04:05Z"}`,
+			event:   func() any { return &models.StockChangedEvent{} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upcast := tt.event()
+			if err := r.Decode(tt.subject, []byte(tt.legacy), upcast); err != nil {
+				t.Fatalf("decode legacy: %v", err)
+			}
+			if got := schemaVersionOf(t, upcast); got != r.Current(tt.subject) {
+				t.Fatalf("schema_version after upcast = %d, want %d", got, r.Current(tt.subject))
+			}
+
+			// 업캐스트 결과는 현재 버전으로 다시 인코딩/디코딩해도 같아야 함
+			encoded, err := r.Encode(tt.subject, upcast)
+			if err != nil {
+				t.Fatalf("encode upcast result: %v", err)
+			}
+			decoded := tt.event()
+			if err := r.Decode(tt.subject, encoded, decoded); err != nil {
+				t.Fatalf("decode current: %v", err)
+			}
+			if !reflect.DeepEqual(upcast, decoded) {
+				t.Fatalf("round trip mismatch:\n  got  %+v\n  want %+v", decoded, upcast)
+			}
+		})
+	}
+}
+
+func TestDecodeUnsupportedVersion(t *testing.T) {
+	r := MustLoad()
+	var event models.StockChangedEvent
+	err := r.Decode(StockChanged, []byte(`{"schema_version":99}`), &event)
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("err = %v, want ErrUnsupportedVersion", err)
+	}
+	var schemaErr *Error
+	if !errors.As(err, &schemaErr) || schemaErr.Version != 99 {
+		t.Fatalf("err = %#v, want *Error for v99", err)
+	}
+}
+
+func TestEncodeRejectsInvalidEvent(t *testing.T) {
+	r := MustLoad()
+	event := models.StockChangedEvent{SchemaVersion: r.Current(StockChanged), ProductID: 1, Reason: "unknown"}
+	if _, err := r.Encode(StockChanged, event); !errors.As(err, new(*Error)) {
+		t.Fatalf("err = %v, want *schema.Error", err)
+	}
+}
+
+func schemaVersionOf(t *testing.T, v any) int {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc.SchemaVersion
+}
@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"productfc/models"
+)
+
+// samples/<subject>/v<N>.json — 버전별 골든 샘플. 한 이벤트의 샘플은 모두 같은 내용을 담아
+// 업캐스트 결과가 현재 버전 샘플과 같아야 함.
+//
+//go:embed samples
+var sampleFS embed.FS
+
+// eventTypes — 스키마별 Go 이벤트 타입 (샘플 디코드 검증용).
+var eventTypes = map[string]func() any{
+	OrderCreated:     func() any { return &models.OrderCreatedEvent{} },
+	StockUpdated:     func() any { return &models.ProductStockUpdatedEvent{} },
+	StockRollback:    func() any { return &models.ProductStockRollbackEvent{} },
+	StockReservation: func() any { return &models.StockReservationEvent{} },
+	StockChanged:     func() any { return &models.StockChangedEvent{} },
+	StockLevel:       func() any { return &models.StockLevelEvent{} },
+	StockBackorder:   func() any { return &models.StockBackorderEvent{} },
+	ProductChanged:   func() any { return &models.ProductChangedEvent{} },
+	CategoryChanged:  func() any { return &models.CategoryChangedEvent{} },
+}
+
+// SampleResult — 골든 샘플 한 개의 검증 결과.
+type SampleResult struct {
+	Subject string
+	Version int
+	Err     error
+}
+
+// Verify — 모든 골든 샘플을 Decode(검증+업캐스트) → Encode 왕복하고 현재 버전 샘플과 비교.
+// 스키마마다 모든 버전에 샘플이 있어야 함. (`productfc schema verify`)
+func (r *Registry) Verify() ([]SampleResult, error) {
+	names := make([]string, 0, len(r.subjects))
+	for name := range r.subjects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []SampleResult
+	for _, name := range names {
+		s := r.subjects[name]
+		newEvent, ok := eventTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("schema %s: no event type registered", name)
+		}
+
+		current, err := r.roundTrip(name, newEvent, s.current)
+		if err != nil {
+			results = append(results, SampleResult{Subject: name, Version: s.current, Err: err})
+			continue
+		}
+		results = append(results, SampleResult{Subject: name, Version: s.current})
+
+		versions := make([]int, 0, len(s.versions))
+		for v := range s.versions {
+			if v != s.current {
+				versions = append(versions, v)
+			}
+		}
+		sort.Ints(versions)
+		for _, v := range versions {
+			got, err := r.roundTrip(name, newEvent, v)
+			if err == nil && !bytes.Equal(got, current) {
+				err = fmt.Errorf("upcast result differs from v%d sample:\n  got  %s\n  want %s", s.current, got, current)
+			}
+			results = append(results, SampleResult{Subject: name, Version: v, Err: err})
+		}
+	}
+
+	if err := r.checkSampleFiles(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *Registry) roundTrip(name string, newEvent func() any, version int) ([]byte, error) {
+	data, err := sampleFS.ReadFile(samplePath(name, version))
+	if err != nil {
+		return nil, fmt.Errorf("missing golden sample: %w", err)
+	}
+	event := newEvent()
+	if err := r.Decode(name, data, event); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	out, err := r.Encode(name, event)
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return out, nil
+}
+
+// checkSampleFiles — 스키마 없는 버전의 샘플이 남아 있으면 오류 (스키마 삭제/이름 변경 누락).
+func (r *Registry) checkSampleFiles() error {
+	return fs.WalkDir(sampleFS, "samples", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name := path.Base(path.Dir(p))
+		version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path.Base(p), "v"), ".json"))
+		if err != nil {
+			return fmt.Errorf("unexpected sample file %s", p)
+		}
+		if s, ok := r.subjects[name]; !ok || s.versions[version] == nil {
+			return fmt.Errorf("sample %s has no matching schema", p)
+		}
+		return nil
+	})
+}
+
+func samplePath(name string, version int) string {
+	return path.Join("samples", name, fmt.Sprintf("v%d.json", version))
+}
+
+// Sample — 골든 샘플 원문 (문서/디버깅용).
+func Sample(name string, version int) (json.RawMessage, error) {
+	return sampleFS.ReadFile(samplePath(name, version))
+}
@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/schema"
+	"productfc/models"
+	"strconv"
+)
+
+// PublishStockChanged — stock.changed를 상품 ID 키로 발행.
+func (p *Producer) PublishStockChanged(ctx context.Context, event models.StockChangedEvent) error {
+	id := strconv.FormatInt(event.ProductID, 10)
+	return p.publishEvent(ctx, p.topics.StockChanged, []byte(id), schema.StockChanged, cloudevents.Event{
+		Type:    p.events.Type(TopicStockChanged),
+		Subject: id,
+		Time:    event.EventTime,
+	}, event)
+}
+
+// PublishStockLevel — stock.low / stock.out / stock.back_in_stock을 상품 ID 키로 발행.
+func (p *Producer) PublishStockLevel(ctx context.Context, event models.StockLevelEvent) error {
+	var topic string
+	switch event.EventType {
+	case models.StockEventLow:
+		topic = p.topics.StockLow
+	case models.StockEventOut:
+		topic = p.topics.StockOut
+	case models.StockEventBackInStock:
+		topic = p.topics.StockBackInStock
+	default:
+		return fmt.Errorf("unknown stock level event type %q", event.EventType)
+	}
+	id := strconv.FormatInt(event.ProductID, 10)
+	return p.publishEvent(ctx, topic, []byte(id), schema.StockLevel, cloudevents.Event{
+		Type:    p.events.Type(event.EventType),
+		Subject: id,
+		Time:    event.EventTime,
+	}, event)
+}
+
+// PublishStockBackorder — stock.backordered / stock.backorder_allocated를 주문 ID 키로 발행.
+func (p *Producer) PublishStockBackorder(ctx context.Context, event models.StockBackorderEvent) error {
+	var topic string
+	switch event.EventType {
+	case models.StockEventBackordered:
+		topic = p.topics.StockBackordered
+	case models.StockEventBackorderAllocated:
+		topic = p.topics.StockBackorderAllocated
+	default:
+		return fmt.Errorf("unknown stock backorder event type %q", event.EventType)
+	}
+	id := strconv.FormatInt(event.OrderID, 10)
+	return p.publishEvent(ctx, topic, []byte(id), schema.StockBackorder, cloudevents.Event{
+		Type:    p.events.Type(event.EventType),
+		Subject: id,
+		Time:    event.EventTime,
+	}, event)
+}
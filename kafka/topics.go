@@ -0,0 +1,35 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// EnsureCompactedTopic — cleanup.policy=compact 토픽이 없으면 생성 (이미 있으면 무시).
+// 파티션/복제 수는 브로커 기본값.
+func EnsureCompactedTopic(ctx context.Context, conn *Connection, topic string) error {
+	client := &kafka.Client{
+		Addr:      kafka.TCP(conn.Brokers...),
+		Transport: conn.Transport,
+	}
+	resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{
+			Topic:             topic,
+			NumPartitions:     -1,
+			ReplicationFactor: -1,
+			ConfigEntries: []kafka.ConfigEntry{
+				{ConfigName: "cleanup.policy", ConfigValue: "compact"},
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create topic %s: %w", topic, err)
+	}
+	if err := resp.Errors[topic]; err != nil && !errors.Is(err, kafka.TopicAlreadyExists) {
+		return fmt.Errorf("failed to create topic %s: %w", topic, err)
+	}
+	return nil
+}
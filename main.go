@@ -2,16 +2,28 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	dlqcli "productfc/cmd/dlq/cli"
+	dlqhandler "productfc/cmd/dlq/handler"
+	dlqrepository "productfc/cmd/dlq/repository"
+	dlqservice "productfc/cmd/dlq/service"
+	dlqusecase "productfc/cmd/dlq/usecase"
 	"productfc/cmd/product/handler"
 	"productfc/cmd/product/repository"
 	"productfc/cmd/product/resource"
 	"productfc/cmd/product/service"
 	"productfc/cmd/product/usecase"
+	schemacli "productfc/cmd/schema/cli"
 	"productfc/config"
 	"productfc/infrastructure/kafkamonitor"
 	"productfc/infrastructure/log"
 	"productfc/infrastructure/redismonitor"
 	kafkapkg "productfc/kafka"
+	"productfc/kafka/cloudevents"
+	"productfc/kafka/codec"
 	"productfc/kafka/consumer"
 	"productfc/kafka/dlq"
 	"productfc/kafka/idempotency"
@@ -19,10 +31,22 @@ import (
 	"productfc/models"
 	"productfc/routes"
 	"productfc/tracing"
+	"sync"
+	"syscall"
+	"time"
 
 	_ "productfc/docs"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	defaultShutdownTimeout = 30 * time.Second
+	dlqMetricsInterval     = 30 * time.Second
+	// processedEventsPurgeInterval — 보관 기간이 지난 멱등성 완료 기록 정리 주기
+	processedEventsPurgeInterval = time.Hour
 )
 
 // @title           PRODUCTFC API
@@ -32,71 +56,154 @@ import (
 // @BasePath        /
 // @schemes         http
 func main() {
+	// 스키마 서브커맨드는 설정/인프라 없이 실행: productfc schema <list|verify>
+	if len(os.Args) > 1 && os.Args[1] == "schema" {
+		os.Exit(schemacli.Run(os.Args[2:], os.Stdout))
+	}
+
 	cfg := config.LoadConfig()
 
 	log.SetupLogger()
 
+	kafkaConn, err := kafkapkg.NewConnection(cfg.Kafka)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Invalid kafka configuration")
+	}
+	topics := kafkapkg.ResolveTopics(cfg.Kafka.Topics)
+	codecs, err := codec.NewSelector(cfg.Kafka.Codec, topics.Named())
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Invalid kafka codec configuration")
+	}
+	cloudEvents, err := cloudevents.NewEncoder(cfg.Kafka.CloudEvents, topics.Named())
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Invalid kafka cloudevents configuration")
+	}
+
+	// 관리용 서브커맨드: productfc dlq <list|show|replay|resolve|stats>
+	if len(os.Args) > 1 && os.Args[1] == "dlq" {
+		os.Exit(runDLQCommand(cfg, kafkaConn, topics, codecs, cloudEvents, os.Args[2:]))
+	}
+
 	// Tracing 초기화
 	shutdownTracer, err := tracing.InitTracer(cfg.Tracing)
 	if err != nil {
 		log.Logger.Warn().Err(err).Msg("Failed to initialize tracing - continuing without tracing")
-	} else {
-		defer shutdownTracer(context.Background())
+		shutdownTracer = func(context.Context) error { return nil }
 	}
 
-	redis := resource.InitRedis(cfg.Redis)
+	appCache := resource.InitCache(cfg.Cache, cfg.Redis)
 	db := resource.InitDB(cfg.Database)
 
-	resource.RedisMonitor = redismonitor.NewMonitor(redis)
+	resource.RedisMonitor = redismonitor.NewMonitor(appCache)
 
 	// AutoMigrate: 데이터베이스 테이블 자동 생성/업데이트
-	if err := db.AutoMigrate(&models.ProductCategory{}, &models.Product{}); err != nil {
+	if err := db.AutoMigrate(
+		&models.ProductCategory{}, &models.Product{}, &models.DLQMessage{},
+		&models.StockThreshold{}, &models.StockAlertState{}, &models.ProcessedEvent{},
+		&models.StockReservation{}, &models.StockReservationLine{},
+		&models.BackorderSetting{}, &models.StockBackorder{}, &models.StockAdjustment{},
+		&models.PurchaseOrder{}, &models.PurchaseOrderLine{}, &models.PurchaseOrderReceipt{},
+	); err != nil {
 		log.Logger.Fatal().Err(err).Msg("Failed to migrate database")
 	}
 	log.Logger.Info().Msg("Database migration completed")
 
-	productRepository := repository.NewProductRepository(db, redis)
-	productService := service.NewProductService(*productRepository, resource.RedisMonitor)
+	resource.KafkaMonitor = kafkamonitor.NewMonitor()
+	kafkaProducer := kafkapkg.NewProducer(kafkaConn, topics, codecs, cloudEvents, resource.KafkaMonitor)
+
+	ensureCtx, cancelEnsure := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := kafkapkg.EnsureCompactedTopic(ensureCtx, kafkaConn, topics.ProductSnapshot); err != nil {
+		log.Logger.Warn().Err(err).Msg("Failed to ensure compacted product snapshot topic")
+	}
+	cancelEnsure()
+
+	productRepository := repository.NewProductRepository(db, appCache)
+	productService := service.NewProductService(*productRepository, resource.RedisMonitor, kafkaProducer, cfg.Stock)
 	productUsecase := usecase.NewProductUsecase(*productService)
 	productHandler := handler.NewProductHandler(*productUsecase)
 
-	brokers := []string{"kafka:9092"}
-	idemStore := idempotency.NewStore(redis)
-	kafkaProducer := kafkapkg.NewProducer(brokers)
-	dlqOrderCreated := dlq.NewPublisher(brokers, kafkapkg.TopicDLQOrderCreated)
-	dlqUpdated := dlq.NewPublisher(brokers, kafkapkg.TopicDLQStockUpdated)
-	dlqRollback := dlq.NewPublisher(brokers, kafkapkg.TopicDLQStockRollback)
-	defer func() {
-		_ = kafkaProducer.Close()
-		_ = dlqOrderCreated.Close()
-		_ = dlqUpdated.Close()
-		_ = dlqRollback.Close()
-	}()
-	resource.KafkaMonitor = kafkamonitor.NewMonitor()
+	idemStore, err := idempotency.NewStore(cfg.Kafka.Idempotency, appCache, db)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Invalid kafka idempotency configuration")
+	}
 
-	go func() {
-		orderCreatedConsumer := consumer.NewOrderCreatedConsumer(
-			brokers, kafkapkg.TopicOrderCreated, productService, kafkaProducer, idemStore, dlqOrderCreated, resource.KafkaMonitor,
-		)
-		orderCreatedConsumer.Start(context.Background())
-	}()
-	log.Logger.Info().Msg("Kafka order.created consumer started")
+	dlqRepository := dlqrepository.NewDLQRepository(db)
+	dlqService := dlqservice.NewDLQService(*dlqRepository, kafkaProducer)
+	dlqUsecase := dlqusecase.NewDLQUsecase(*dlqService)
+	dlqHandler := dlqhandler.NewDLQHandler(*dlqUsecase)
 
-	go func() {
-		kafkaProductUpdateStockConsumer := consumer.NewProductUpdateStockConsumer(
-			brokers, kafkapkg.TopicStockUpdated, productService, idemStore, dlqUpdated, resource.KafkaMonitor,
+	dlqOrderCreated := dlq.NewPublisher(kafkaConn, topics.DLQOrderCreated, cloudEvents, resource.KafkaMonitor)
+	dlqUpdated := dlq.NewPublisher(kafkaConn, topics.DLQStockUpdated, cloudEvents, resource.KafkaMonitor)
+	dlqRollback := dlq.NewPublisher(kafkaConn, topics.DLQStockRollback, cloudEvents, resource.KafkaMonitor)
+
+	// SIGINT/SIGTERM 수신 시 종료 절차 시작
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// 컨슈머는 별도 컨텍스트 — HTTP 서버를 먼저 내린 뒤 취소
+	consumerCtx, cancelConsumers := context.WithCancel(context.Background())
+	defer cancelConsumers()
+	var consumerWG sync.WaitGroup
+
+	// kafka.consumers.<name>.enabled: false인 컨슈머는 띄우지 않음
+	var consumers []namedConsumer
+	addConsumer := func(name, topic string, newConsumer func() kafkaConsumer) {
+		if !cfg.Kafka.Consumers[name].IsEnabled() {
+			log.Logger.Info().Msgf("Kafka %s consumer disabled by config", name)
+			return
+		}
+		consumers = append(consumers, namedConsumer{name: name, topic: topic, consumer: newConsumer()})
+	}
+	addConsumer("order_created", topics.OrderCreated, func() kafkaConsumer {
+		return consumer.NewOrderCreatedConsumer(
+			consumer.ConfigFor(cfg.Kafka, kafkaConn, "order_created", topics.OrderCreated),
+			productService, kafkaProducer, idemStore, dlqOrderCreated, resource.KafkaMonitor,
 		)
-		kafkaProductUpdateStockConsumer.Start(context.Background())
-	}()
-	log.Logger.Info().Msg("Kafka stock.updated consumer started")
+	})
+	addConsumer("stock_updated", topics.StockUpdated, func() kafkaConsumer {
+		return consumer.NewProductUpdateStockConsumer(
+			consumer.ConfigFor(cfg.Kafka, kafkaConn, "stock_updated", topics.StockUpdated),
+			productService, idemStore, dlqUpdated, resource.KafkaMonitor,
+		)
+	})
+	addConsumer("stock_rollback", topics.StockRollback, func() kafkaConsumer {
+		return consumer.NewProductRollbackStockConsumer(
+			consumer.ConfigFor(cfg.Kafka, kafkaConn, "stock_rollback", topics.StockRollback),
+			productService, idemStore, dlqRollback, resource.KafkaMonitor,
+		)
+	})
+	// DLQ 토픽 수집 → dlq_messages (관리 API/CLI 조회·재처리용)
+	for _, topic := range []string{topics.DLQOrderCreated, topics.DLQStockUpdated, topics.DLQStockRollback} {
+		addConsumer("dlq_indexer", topic, func() kafkaConsumer {
+			return consumer.NewDLQIndexerConsumer(
+				consumer.ConfigFor(cfg.Kafka, kafkaConn, "dlq_indexer", topic),
+				dlqService, resource.KafkaMonitor,
+			)
+		})
+	}
+
+	for _, c := range consumers {
+		consumerWG.Add(1)
+		go func(c kafkaConsumer) {
+			defer consumerWG.Done()
+			c.Start(consumerCtx)
+		}(c.consumer)
+		log.Logger.Info().Str("topic", c.topic).Msgf("Kafka %s consumer started", c.name)
+	}
 
+	consumerWG.Add(1)
 	go func() {
-		kafkaProductRollbackConsumer := consumer.NewProductRollbackStockConsumer(
-			brokers, kafkapkg.TopicStockRollback, productService, idemStore, dlqRollback, resource.KafkaMonitor,
-		)
-		kafkaProductRollbackConsumer.Start(context.Background())
+		defer consumerWG.Done()
+		dlqService.RefreshMetrics(consumerCtx, dlqMetricsInterval)
 	}()
-	log.Logger.Info().Msg("Kafka stock.rollback consumer started")
+
+	if pgStore, ok := idemStore.(*idempotency.PostgresStore); ok {
+		consumerWG.Add(1)
+		go func() {
+			defer consumerWG.Done()
+			pgStore.RunPurge(consumerCtx, processedEventsPurgeInterval)
+		}()
+	}
 
 	port := cfg.App.Port
 	router := gin.Default()
@@ -107,8 +214,109 @@ func main() {
 		router.Use(tracing.GinMiddleware(cfg.Tracing.ServiceName))
 	}
 
-	routes.SetupRoutes(router, productHandler)
+	routes.SetupRoutes(router, productHandler, dlqHandler)
+
+	server := &http.Server{
+		Addr:    ":" + port,
+		Handler: router,
+	}
+	go func() {
+		log.Logger.Info().Msgf("Server is running on port %s", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Logger.Fatal().Err(err).Msg("HTTP server failed")
+		}
+	}()
+
+	<-signalCtx.Done()
+	stop()
+	log.Logger.Info().Msg("Shutdown signal received - draining HTTP requests and Kafka consumers")
+
+	shutdownTimeout := cfg.App.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	// 1. 새 HTTP 요청 수락 중단 + 처리 중인 요청 완료 대기
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Logger.Error().Err(err).Msg("HTTP server shutdown did not complete")
+	}
+
+	// 2. 컨슈머 읽기 중단 → 처리 중인 메시지 완료 대기 (deadline까지)
+	cancelConsumers()
+	if !waitWithContext(shutdownCtx, &consumerWG) {
+		log.Logger.Warn().Msg("Timed out waiting for in-flight Kafka messages")
+	}
+
+	// 3. Kafka reader/writer, Redis, DB, tracer 순으로 정리
+	for _, c := range consumers {
+		closeAll("kafka reader", c.consumer.Close)
+	}
+	closeAll("kafka writer",
+		kafkaProducer.Close,
+		dlqOrderCreated.Close,
+		dlqUpdated.Close,
+		dlqRollback.Close,
+	)
+	closeAll("cache", appCache.Close)
+	if sqlDB, err := db.DB(); err == nil {
+		closeAll("database", sqlDB.Close)
+	}
+	if err := shutdownTracer(shutdownCtx); err != nil {
+		log.Logger.Error().Err(err).Msg("Failed to flush tracer")
+	}
+
+	log.Logger.Info().Msg("Shutdown completed")
+}
+
+// kafkaConsumer — main에서 기동/종료를 관리하는 컨슈머 공통 동작.
+type kafkaConsumer interface {
+	Start(ctx context.Context)
+	Close() error
+}
+
+type namedConsumer struct {
+	name     string
+	topic    string
+	consumer kafkaConsumer
+}
+
+// runDLQCommand — DLQ 관리 CLI. HTTP 서버/컨슈머 없이 DB와 Kafka producer만 사용.
+// 재처리 결과가 API 재처리와 같도록 producer는 서버와 같은 codec/CloudEvents 설정으로 생성.
+func runDLQCommand(cfg config.Config, kafkaConn *kafkapkg.Connection, topics kafkapkg.Topics, codecs *codec.Selector, cloudEvents *cloudevents.Encoder, args []string) int {
+	db := resource.InitDB(cfg.Database)
+	db = db.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Warn)})
+	if err := db.AutoMigrate(&models.DLQMessage{}); err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to migrate database")
+	}
 
-	log.Logger.Info().Msgf("Server is running on port %s", port)
-	router.Run(":" + port)
+	kafkaProducer := kafkapkg.NewProducer(kafkaConn, topics, codecs, cloudEvents, nil)
+	defer kafkaProducer.Close()
+
+	dlqService := dlqservice.NewDLQService(*dlqrepository.NewDLQRepository(db), kafkaProducer)
+	return dlqcli.Run(context.Background(), dlqService, args, os.Stdout)
+}
+
+// waitWithContext — wg 완료 시 true, ctx 만료 시 false.
+func waitWithContext(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func closeAll(name string, closers ...func() error) {
+	for _, closeFn := range closers {
+		if err := closeFn(); err != nil {
+			log.Logger.Error().Err(err).Msgf("Failed to close %s", name)
+		}
+	}
 }
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// 관리자 재고 조정 사유 (stocktake는 실사 업로드로만 기록).
+const (
+	StockAdjustReasonDamage    = "damage"
+	StockAdjustReasonRecount   = "recount"
+	StockAdjustReasonReceipt   = "receipt"
+	StockAdjustReasonStocktake = "stocktake"
+)
+
+var (
+	ErrInvalidAdjustment  = errors.New("invalid stock adjustment")
+	ErrInvalidStocktake   = errors.New("invalid stocktake")
+	ErrNegativeAdjustment = errors.New("adjustment would make stock negative")
+)
+
+// StockAdjustment — 관리자 재고 조정/실사 이력 한 건 (상품별).
+type StockAdjustment struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   int64     `gorm:"not null;index" json:"product_id"`
+	Reason      string    `gorm:"type:varchar(20);not null" json:"reason"`
+	Delta       int       `gorm:"type:integer;not null" json:"delta"`
+	OldStock    int       `gorm:"type:integer;not null" json:"old_stock"`
+	NewStock    int       `gorm:"type:integer;not null" json:"new_stock"`
+	Note        string    `gorm:"type:text" json:"note,omitempty"`
+	StocktakeID string    `gorm:"type:uuid;index" json:"stocktake_id,omitempty"`
+	AdjustedBy  int64     `json:"adjusted_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// StockAdjustmentRequest — 상대 수량 조정. damage는 음수, receipt는 양수만.
+type StockAdjustmentRequest struct {
+	Delta  int    `json:"delta" binding:"required,ne=0"`
+	Reason string `json:"reason" binding:"required,oneof=damage recount receipt"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+// StocktakeRequest — 실사 수량 일괄 업로드 (절대 수량으로 덮어씀).
+type StocktakeRequest struct {
+	Items []StocktakeItem `json:"items" binding:"required,min=1,dive"`
+	Note  string          `json:"note" binding:"max=500"`
+}
+
+type StocktakeItem struct {
+	ProductID int64 `json:"product_id" binding:"required,min=1"`
+	Counted   *int  `json:"counted" binding:"required,min=0"`
+}
+
+// StocktakeVariance — 실사 한 상품의 결과. Expected는 실물 재고(재고 + 미할당 백오더), Variance = Counted - Expected.
+type StocktakeVariance struct {
+	ProductID int64 `json:"product_id"`
+	Expected  int   `json:"expected"`
+	Counted   int   `json:"counted"`
+	Variance  int   `json:"variance"`
+}
+
+type StocktakeResult struct {
+	StocktakeID   string              `json:"stocktake_id"`
+	Items         []StocktakeVariance `json:"items"`
+	TotalVariance int                 `json:"total_variance"`
+	Mismatched    int                 `json:"mismatched"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+const (
+	BackorderModeBackorder = "backorder"
+	BackorderModePreorder  = "preorder"
+
+	BackorderStatusPending   = "pending"
+	BackorderStatusAllocated = "allocated"
+	BackorderStatusCancelled = "cancelled"
+)
+
+// 백오더 이벤트 종류 (기본 토픽명과 같음).
+const (
+	StockEventBackordered        = "stock.backordered"
+	StockEventBackorderAllocated = "stock.backorder_allocated"
+)
+
+// BackorderSetting — 상품별 백오더/예약 주문 허용 설정.
+// MaxQuantity는 재고가 내려갈 수 있는 최대 음수 폭 (미할당 백오더 수량 상한, 0이면 무제한).
+type BackorderSetting struct {
+	ProductID         int64      `gorm:"primaryKey;autoIncrement:false" json:"product_id"`
+	Allowed           bool       `gorm:"not null;default:false" json:"allowed"`
+	Mode              string     `gorm:"type:varchar(10);not null;default:backorder" json:"mode"`
+	MaxQuantity       int        `gorm:"type:integer;not null;default:0" json:"max_quantity"`
+	ExpectedRestockAt *time.Time `json:"expected_restock_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type BackorderSettingRequest struct {
+	Allowed           bool       `json:"allowed"`
+	Mode              string     `json:"mode" binding:"omitempty,oneof=backorder preorder"`
+	MaxQuantity       int        `json:"max_quantity" binding:"min=0"`
+	ExpectedRestockAt *time.Time `json:"expected_restock_at"`
+}
+
+// Orderable — 현재 재고에서 받을 수 있는 주문 수량. unlimited면 수량 제한 없음.
+func (s BackorderSetting) Orderable(stock int) (qty int, unlimited bool) {
+	if !s.Allowed {
+		return max(stock, 0), false
+	}
+	if s.MaxQuantity == 0 {
+		return 0, true
+	}
+	return max(stock+s.MaxQuantity, 0), false
+}
+
+// StockBackorder — 재고가 없어 음수 재고로 받은 주문 라인의 미입고분. 입고되면 먼저 받은 순서(FIFO)로 할당.
+type StockBackorder struct {
+	ID                int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID           int64      `gorm:"not null;index" json:"order_id"`
+	ReservationID     string     `gorm:"type:uuid;not null;index:idx_stock_backorders_line" json:"reservation_id"`
+	LineID            int64      `gorm:"not null;index:idx_stock_backorders_line" json:"line_id"`
+	ProductID         int64      `gorm:"not null;index:idx_stock_backorders_product_status" json:"product_id"`
+	Mode              string     `gorm:"type:varchar(10);not null" json:"mode"`
+	ExpectedRestockAt *time.Time `json:"expected_restock_at,omitempty"`
+	Quantity          int        `gorm:"type:integer;not null" json:"quantity"`
+	AllocatedQuantity int        `gorm:"type:integer;not null;default:0" json:"allocated_quantity"`
+	CancelledQuantity int        `gorm:"type:integer;not null;default:0" json:"cancelled_quantity"`
+	Status            string     `gorm:"type:varchar(10);not null;index:idx_stock_backorders_product_status" json:"status"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Pending — 아직 할당되지 않은 수량.
+func (b StockBackorder) Pending() int {
+	return b.Quantity - b.AllocatedQuantity - b.CancelledQuantity
+}
+
+// BackorderAllocation — 입고로 백오더 한 건에 이번에 할당한 수량 (Backorder는 할당 후 상태).
+type BackorderAllocation struct {
+	Backorder StockBackorder
+	Quantity  int
+}
+
+// BackorderItem — 백오더 이벤트 라인. Remaining은 이 이벤트 이후 남은 미할당 수량.
+type BackorderItem struct {
+	ProductID         int64      `json:"product_id"`
+	LineID            int64      `json:"line_id"`
+	Quantity          int        `json:"quantity"`
+	Remaining         int        `json:"remaining"`
+	Mode              string     `json:"mode"`
+	ExpectedRestockAt *time.Time `json:"expected_restock_at,omitempty"`
+}
+
+// StockBackorderEvent — stock.backordered(음수 재고로 주문 수락) / stock.backorder_allocated(입고분 할당), 주문 단위.
+type StockBackorderEvent struct {
+	SchemaVersion int             `json:"schema_version"`
+	EventType     string          `json:"event_type"`
+	OrderID       int64           `json:"order_id"`
+	ReservationID string          `json:"reservation_id"`
+	Items         []BackorderItem `json:"items"`
+	EventTime     time.Time       `json:"event_time"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// 카탈로그 변경 이벤트 종류 (기본 토픽명과 같음).
+const (
+	ProductEventCreated  = "product.created"
+	ProductEventUpdated  = "product.updated"
+	ProductEventDeleted  = "product.deleted"
+	CategoryEventCreated = "category.created"
+	CategoryEventUpdated = "category.updated"
+	CategoryEventDeleted = "category.deleted"
+)
+
+// ProductChangedEvent — 상품 생성/수정/삭제. created는 Before 없음, deleted는 After 없음.
+type ProductChangedEvent struct {
+	SchemaVersion int       `json:"schema_version"`
+	EventType     string    `json:"event_type"`
+	ProductID     int64     `json:"product_id"`
+	Before        *Product  `json:"before,omitempty"`
+	After         *Product  `json:"after,omitempty"`
+	EventTime     time.Time `json:"event_time"`
+}
+
+// CategoryChangedEvent — 카테고리 생성/수정/삭제.
+type CategoryChangedEvent struct {
+	SchemaVersion int              `json:"schema_version"`
+	EventType     string           `json:"event_type"`
+	CategoryID    int              `json:"category_id"`
+	Before        *ProductCategory `json:"before,omitempty"`
+	After         *ProductCategory `json:"after,omitempty"`
+	EventTime     time.Time        `json:"event_time"`
+}
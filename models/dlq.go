@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	DLQStatusPending  = "pending"
+	DLQStatusReplayed = "replayed"
+	DLQStatusResolved = "resolved"
+)
+
+var (
+	ErrDLQMessageNotFound = errors.New("dlq message not found")
+	ErrDLQMessageResolved = errors.New("dlq message already resolved")
+	ErrDLQInvalidReplay   = errors.New("dlq message cannot be replayed")
+)
+
+const (
+	DLQBodyEncodingJSON   = "json"
+	DLQBodyEncodingBase64 = "base64"
+)
+
+// DLQMessage — *.dlq 토픽에서 수집한 실패 메시지 (조회/재처리/해결 표시용).
+type DLQMessage struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	DLQTopic      string `gorm:"type:varchar(255);not null;uniqueIndex:idx_dlq_messages_position;index:idx_dlq_messages_topic_status" json:"dlq_topic"`
+	Partition     int    `gorm:"type:integer;not null;uniqueIndex:idx_dlq_messages_position" json:"partition"`
+	Offset        int64  `gorm:"type:bigint;not null;uniqueIndex:idx_dlq_messages_position" json:"offset"`
+	OriginalTopic string `gorm:"type:varchar(255);not null" json:"original_topic"`
+	// 원본 메시지 출처 (재시도 tier를 거쳤어도 최초 토픽 기준)
+	OriginalPartition int        `gorm:"type:integer;not null;default:0" json:"original_partition"`
+	OriginalOffset    int64      `gorm:"type:bigint;not null;default:0" json:"original_offset"`
+	MessageKey        string     `gorm:"type:varchar(255)" json:"message_key,omitempty"`
+	Headers           string     `gorm:"type:text" json:"headers,omitempty"`
+	ConsumerGroup     string     `gorm:"type:varchar(255)" json:"consumer_group,omitempty"`
+	Attempts          int        `gorm:"type:integer;not null;default:0" json:"attempts"`
+	ErrorClass        string     `gorm:"type:varchar(20);index:idx_dlq_messages_error_class" json:"error_class"`
+	FirstFailedAt     *time.Time `json:"first_failed_at,omitempty"`
+	FailedAt          *time.Time `json:"failed_at,omitempty"`
+	OrderID           int64      `gorm:"type:bigint;index:idx_dlq_messages_order" json:"order_id,omitempty"`
+	Error             string     `gorm:"type:text" json:"error"`
+	Body              string     `gorm:"type:text" json:"body"`
+	// BodyEncoding — json 또는 base64 (JSON이 아닌 원문, 예: 디코드 실패)
+	BodyEncoding string     `gorm:"type:varchar(10);not null;default:json" json:"body_encoding"`
+	Status       string     `gorm:"type:varchar(20);not null;default:pending;index:idx_dlq_messages_topic_status" json:"status"`
+	ReplayCount  int        `gorm:"type:integer;not null;default:0" json:"replay_count"`
+	ReplayedBody string     `gorm:"type:text" json:"replayed_body,omitempty"`
+	ReplayedAt   *time.Time `json:"replayed_at,omitempty"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	ResolveNote  string     `gorm:"type:text" json:"resolve_note,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type DLQSearchParameter struct {
+	DLQTopic      string `json:"dlq_topic"`
+	OriginalTopic string `json:"original_topic"`
+	OrderID       int64  `json:"order_id"`
+	ErrorContains string `json:"error"`
+	ErrorClass    string `json:"error_class"`
+	Status        string `json:"status"`
+	Page          int    `json:"page"`
+	PageSize      int    `json:"pageSize"`
+}
+
+type DLQSearchResponse struct {
+	Messages   []DLQMessage `json:"messages"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"pageSize"`
+	TotalCount int          `json:"totalCount"`
+	TotalPages int          `json:"totalPages"`
+}
+
+// DLQCount — DLQ 토픽/상태별 건수 (메트릭, 통계 API).
+type DLQCount struct {
+	DLQTopic string `json:"dlq_topic"`
+	Status   string `json:"status"`
+	Count    int64  `json:"count"`
+}
+
+type DLQReplayRequest struct {
+	// Body — 수정 후 재처리할 이벤트 JSON 본문 (생략 시 원본 그대로). 주면 content-type은 JSON, 이벤트 ID는 새로 발급
+	Body json.RawMessage `json:"body,omitempty" swaggertype:"object"`
+}
+
+type DLQResolveRequest struct {
+	Note string `json:"note"`
+}
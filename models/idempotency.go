@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+const (
+	ProcessedEventStatusProcessing = "processing"
+	ProcessedEventStatusDone       = "done"
+)
+
+// ProcessedEvent — 컨슈머 멱등성 기록 (컨슈머 그룹 + 이벤트 ID).
+// processing은 lease_until까지 한 인스턴스가 점유 중, done은 재고 변경과 같은 트랜잭션에서 기록.
+type ProcessedEvent struct {
+	Key         string     `gorm:"primaryKey;type:varchar(512)" json:"key"`
+	Status      string     `gorm:"type:varchar(20);not null;index:idx_processed_events_status_processed_at" json:"status"`
+	Owner       string     `gorm:"type:varchar(64);not null" json:"owner"`
+	LeaseUntil  *time.Time `json:"lease_until,omitempty"`
+	ProcessedAt *time.Time `gorm:"index:idx_processed_events_status_processed_at" json:"processed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -11,6 +11,7 @@ type ProductStockUpdatedEvent struct {
 }
 
 type OrderCreatedEvent struct {
+	SchemaVersion   int           `json:"schema_version"`
 	OrderID         int64         `json:"order_id"`
 	UserID          int64         `json:"user_id"`
 	TotalAmount     float64       `json:"total_amount"`
@@ -19,17 +20,23 @@ type OrderCreatedEvent struct {
 	Products        []ProductItem `json:"products"`
 }
 
+// ProductItem — 주문 라인. LineID는 주문 안에서 라인을 구분 (없으면 예약 시 순번으로 부여),
+// 롤백 이벤트에서는 복원할 예약 라인을 가리킴.
 type ProductItem struct {
 	ProductID int64 `json:"product_id"`
 	Quantity  int   `json:"quantity"`
+	LineID    int64 `json:"line_id,omitempty"`
 }
 
+// ProductStockRollbackEvent — 주문 전체 또는 일부(라인/수량) 재고 복원.
+// ReservationID가 있으면 그 예약만, 없으면 주문의 모든 예약에서 복원. 예약 수량을 넘는 복원은 거절.
 type ProductStockRollbackEvent struct {
 	SchemaVersion int           `json:"schema_version"`
 	OrderID       int64         `json:"order_id"`
 	UserID        int64         `json:"user_id"`
 	Products      []ProductItem `json:"products"`
 	EventTime     time.Time     `json:"event_time"`
+	ReservationID string        `json:"reservation_id,omitempty"`
 }
 
 type StockReservationEvent struct {
@@ -40,4 +47,8 @@ type StockReservationEvent struct {
 	Products      []ProductItem `json:"products"`
 	Reason        string        `json:"reason,omitempty"`
 	EventTime     time.Time     `json:"event_time"`
+	// ReservationID — stock.reserved일 때 부분 롤백에서 참조할 예약 ID
+	ReservationID string `json:"reservation_id,omitempty"`
+	// Shortfalls — 재고가 모자란 모든 상품. stock.rejected, 또는 부분 충족 시 stock.reserved의 미충족분
+	Shortfalls []StockShortfall `json:"shortfalls,omitempty"`
 }
@@ -1,8 +1,15 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
-var ErrInsufficientStock = errors.New("insufficient stock")
+var (
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrProductNotFound   = errors.New("product not found")
+	ErrCategoryNotFound  = errors.New("category not found")
+)
 
 type ProductCategory struct {
 	ID   int    `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -17,6 +24,9 @@ type Product struct {
 	Stock       int             `gorm:"type:integer;not null" json:"stock"`
 	CategoryID  int             `gorm:"type:integer;not null;index:idx_products_category" json:"category_id"`
 	Category    ProductCategory `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE" json:"category"`
+	// Incoming/RestockETA — 열린 발주의 미입고 수량과 아직 지나지 않은 가장 이른 입고 예정일 (조회 응답용, 이벤트에는 없음)
+	Incoming   int        `gorm:"-" json:"incoming,omitempty"`
+	RestockETA *time.Time `gorm:"-" json:"restock_eta,omitempty"`
 }
 
 type SearchProductParameter struct {
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PurchaseOrderStatusOpen              = "open"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+var (
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrPurchaseOrderClosed   = errors.New("purchase order is closed")
+	ErrInvalidPurchaseOrder  = errors.New("invalid purchase order")
+	ErrInvalidReceipt        = errors.New("invalid purchase order receipt")
+)
+
+// PurchaseOrder — 보충 발주. 입고되지 않은 라인 수량이 상품의 입고 예정(incoming) 수량.
+type PurchaseOrder struct {
+	ID        int64               `gorm:"primaryKey;autoIncrement" json:"id"`
+	Supplier  string              `gorm:"type:varchar(255)" json:"supplier,omitempty"`
+	Status    string              `gorm:"type:varchar(20);not null;index" json:"status"`
+	Note      string              `gorm:"type:text" json:"note,omitempty"`
+	Lines     []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID" json:"lines"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PurchaseOrderID  int64     `gorm:"not null;index" json:"purchase_order_id"`
+	ProductID        int64     `gorm:"not null;index" json:"product_id"`
+	Quantity         int       `gorm:"type:integer;not null" json:"quantity"`
+	ReceivedQuantity int       `gorm:"type:integer;not null;default:0" json:"received_quantity"`
+	ExpectedAt       time.Time `gorm:"not null" json:"expected_at"`
+}
+
+// Remaining — 아직 입고되지 않은 수량.
+func (l PurchaseOrderLine) Remaining() int {
+	return l.Quantity - l.ReceivedQuantity
+}
+
+// PurchaseOrderReceipt — 입고 한 건 (라인별, 부분 입고면 여러 건).
+type PurchaseOrderReceipt struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PurchaseOrderID int64     `gorm:"not null;index" json:"purchase_order_id"`
+	LineID          int64     `gorm:"not null" json:"line_id"`
+	ProductID       int64     `gorm:"not null" json:"product_id"`
+	Quantity        int       `gorm:"type:integer;not null" json:"quantity"`
+	ReceivedBy      int64     `json:"received_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CreatePurchaseOrderRequest — 라인 expected_at이 없으면 발주 expected_at을 사용 (둘 다 없으면 오류).
+type CreatePurchaseOrderRequest struct {
+	Supplier   string                    `json:"supplier" binding:"max=255"`
+	Note       string                    `json:"note" binding:"max=500"`
+	ExpectedAt *time.Time                `json:"expected_at"`
+	Lines      []CreatePurchaseOrderLine `json:"lines" binding:"required,min=1,dive"`
+}
+
+type CreatePurchaseOrderLine struct {
+	ProductID  int64      `json:"product_id" binding:"required,min=1"`
+	Quantity   int        `json:"quantity" binding:"required,min=1"`
+	ExpectedAt *time.Time `json:"expected_at"`
+}
+
+// ReceivePurchaseOrderRequest — 라인별 입고 수량. lines가 비어 있으면 남은 수량 전부 입고.
+type ReceivePurchaseOrderRequest struct {
+	Lines []ReceivePurchaseOrderLine `json:"lines" binding:"dive"`
+}
+
+type ReceivePurchaseOrderLine struct {
+	LineID   int64 `json:"line_id" binding:"required,min=1"`
+	Quantity int   `json:"quantity" binding:"required,min=1"`
+}
+
+// ProductIncoming — 열린 발주의 미입고 수량 합계와 아직 지나지 않은 가장 이른 입고 예정일.
+type ProductIncoming struct {
+	ProductID  int64
+	Quantity   int
+	RestockETA *time.Time
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrReservationNotFound    = errors.New("stock reservation not found")
+	ErrInvalidOrderLines      = errors.New("invalid order lines")
+	ErrInvalidRollback        = errors.New("invalid stock rollback")
+	ErrRestoreExceedsReserved = errors.New("restore exceeds reserved quantity")
+)
+
+// StockReservation — 주문 재고 차감 한 건 (order.created / stock.updated). 부분 롤백의 복원 한도.
+type StockReservation struct {
+	ID        string                 `gorm:"primaryKey;type:uuid" json:"id"`
+	OrderID   int64                  `gorm:"not null;index" json:"order_id"`
+	Lines     []StockReservationLine `gorm:"foreignKey:ReservationID" json:"lines"`
+	CreatedAt time.Time              `json:"created_at"`
+	// Shortfalls — 부분 충족으로 예약하지 못한 수량 (저장하지 않음)
+	Shortfalls []StockShortfall `gorm:"-" json:"shortfalls,omitempty"`
+	// Backorders — 재고가 없어 백오더로 받은 라인 (stock_backorders에 저장)
+	Backorders []StockBackorder `gorm:"-" json:"backorders,omitempty"`
+}
+
+// StockReservationLine — 예약 라인별 차감 수량과 지금까지 복원한 수량.
+type StockReservationLine struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReservationID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_stock_reservation_lines_line" json:"reservation_id"`
+	OrderID          int64     `gorm:"not null;index" json:"order_id"`
+	LineID           int64     `gorm:"not null;uniqueIndex:idx_stock_reservation_lines_line" json:"line_id"`
+	ProductID        int64     `gorm:"not null" json:"product_id"`
+	Quantity         int       `gorm:"type:integer;not null" json:"quantity"`
+	RestoredQuantity int       `gorm:"type:integer;not null;default:0" json:"restored_quantity"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Remaining — 아직 복원할 수 있는 수량.
+func (l StockReservationLine) Remaining() int {
+	return l.Quantity - l.RestoredQuantity
+}
+
+// Items — 예약 라인을 이벤트 라인으로 (stock.reserved).
+func (r StockReservation) Items() []ProductItem {
+	items := make([]ProductItem, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		items = append(items, ProductItem{ProductID: line.ProductID, Quantity: line.Quantity, LineID: line.LineID})
+	}
+	return items
+}
+
+// ShortfallsFor — 주문 라인 대비 예약하지 못한 수량 (상품 ID 순). 부분 충족이면 예약 수량이 곧 주문 가능 수량이라
+// 예약 시 계산한 Shortfalls와 같음.
+func (r StockReservation) ShortfallsFor(items []ProductItem) []StockShortfall {
+	demand := make(map[int64]int, len(items))
+	for _, item := range items {
+		demand[item.ProductID] += item.Quantity
+	}
+	reserved := make(map[int64]int, len(r.Lines))
+	for _, line := range r.Lines {
+		reserved[line.ProductID] += line.Quantity
+	}
+
+	ids := make([]int64, 0, len(demand))
+	for id := range demand {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var shortfalls []StockShortfall
+	for _, id := range ids {
+		if reserved[id] < demand[id] {
+			shortfalls = append(shortfalls, StockShortfall{ProductID: id, Requested: demand[id], Available: reserved[id]})
+		}
+	}
+	return shortfalls
+}
+
+// AssignLineIDs — line_id가 없는 라인에 순번(1부터, 명시된 line_id와 겹치면 다음 빈 번호)을 부여한 복사본.
+// 명시된 line_id가 두 번이면 오류.
+func AssignLineIDs(items []ProductItem) ([]ProductItem, error) {
+	out := make([]ProductItem, len(items))
+	taken := make(map[int64]struct{}, len(items))
+	for _, item := range items {
+		if item.LineID == 0 {
+			continue
+		}
+		if _, ok := taken[item.LineID]; ok {
+			return nil, fmt.Errorf("%w: duplicate line_id %d", ErrInvalidOrderLines, item.LineID)
+		}
+		taken[item.LineID] = struct{}{}
+	}
+	for i, item := range items {
+		if item.LineID == 0 {
+			item.LineID = int64(i + 1)
+			for {
+				if _, ok := taken[item.LineID]; !ok {
+					break
+				}
+				item.LineID++
+			}
+			taken[item.LineID] = struct{}{}
+		}
+		out[i] = item
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAssignLineIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		lineIDs []int64 // 0이면 line_id 생략
+		want    []int64
+		wantErr bool
+	}{
+		{name: "all implicit", lineIDs: []int64{0, 0, 0}, want: []int64{1, 2, 3}},
+		{name: "all explicit", lineIDs: []int64{10, 20}, want: []int64{10, 20}},
+		{name: "explicit id taken by later implicit position", lineIDs: []int64{2, 0}, want: []int64{2, 3}},
+		{name: "explicit id after implicit position", lineIDs: []int64{0, 1}, want: []int64{2, 1}},
+		{name: "implicit skips several taken ids", lineIDs: []int64{0, 1, 2, 0}, want: []int64{3, 1, 2, 4}},
+		{name: "mixed without collision", lineIDs: []int64{0, 7, 0}, want: []int64{1, 7, 3}},
+		{name: "duplicate explicit", lineIDs: []int64{5, 0, 5}, wantErr: true},
+		{name: "empty", lineIDs: nil, want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]ProductItem, len(tt.lineIDs))
+			for i, id := range tt.lineIDs {
+				items[i] = ProductItem{ProductID: int64(i + 100), Quantity: 1, LineID: id}
+			}
+			got, err := AssignLineIDs(items)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOrderLines) {
+					t.Fatalf("error = %v, want ErrInvalidOrderLines", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d lines, want %d", len(got), len(tt.want))
+			}
+			for i, item := range got {
+				if item.LineID != tt.want[i] {
+					t.Fatalf("line %d id = %d, want %d (all %v)", i, item.LineID, tt.want[i], got)
+				}
+				if item.ProductID != items[i].ProductID || item.Quantity != items[i].Quantity {
+					t.Fatalf("line %d = %+v, want product/quantity kept", i, item)
+				}
+				if items[i].LineID != tt.lineIDs[i] {
+					t.Fatalf("input line %d modified", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 재고 수준 — 임계치 알림 중복 방지용 상태.
+const (
+	StockLevelOK  = "ok"
+	StockLevelLow = "low"
+	StockLevelOut = "out"
+)
+
+// 재고 수준 이벤트 종류 (기본 토픽명과 같음).
+const (
+	StockEventLow         = "stock.low"
+	StockEventOut         = "stock.out"
+	StockEventBackInStock = "stock.back_in_stock"
+)
+
+// stock.changed 원인.
+const (
+	StockChangeReserved  = "reserved"
+	StockChangeRestored  = "restored"
+	StockChangeEdited    = "edited"
+	StockChangeAdjusted  = "adjusted"
+	StockChangeStocktake = "stocktake"
+	StockChangeReceived  = "received"
+)
+
+// StockChange — 재고 변경 한 건 (같은 상품이 여러 줄이면 합산: 처음 재고 → 최종 재고).
+type StockChange struct {
+	ProductID  int64
+	CategoryID int
+	OldStock   int
+	NewStock   int
+	// Allocated — 이번 입고로 대기 중인 백오더에 할당한 수량
+	Allocated []BackorderAllocation
+}
+
+// StockThreshold — 저재고 임계치. 상품 설정이 카테고리 설정보다 우선, 둘 다 없으면 config 기본값.
+type StockThreshold struct {
+	ID                int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID         *int64    `gorm:"uniqueIndex" json:"product_id,omitempty"`
+	CategoryID        *int      `gorm:"uniqueIndex" json:"category_id,omitempty"`
+	LowStockThreshold int       `gorm:"type:integer;not null" json:"low_stock_threshold"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// StockAlertState — 상품별 마지막으로 알린 재고 수준 (같은 임계치 이벤트 반복 방지).
+type StockAlertState struct {
+	ProductID int64     `gorm:"primaryKey;autoIncrement:false" json:"product_id"`
+	Level     string    `gorm:"type:varchar(10);not null" json:"level"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type StockThresholdRequest struct {
+	LowStockThreshold int `json:"low_stock_threshold" binding:"min=0"`
+}
+
+type StockChangedEvent struct {
+	SchemaVersion int       `json:"schema_version"`
+	ProductID     int64     `json:"product_id"`
+	CategoryID    int       `json:"category_id"`
+	OldStock      int       `json:"old_stock"`
+	NewStock      int       `json:"new_stock"`
+	Delta         int       `json:"delta"`
+	Reason        string    `json:"reason"`
+	EventTime     time.Time `json:"event_time"`
+}
+
+// StockLevelEvent — stock.low / stock.out / stock.back_in_stock.
+type StockLevelEvent struct {
+	SchemaVersion     int       `json:"schema_version"`
+	EventType         string    `json:"event_type"`
+	ProductID         int64     `json:"product_id"`
+	CategoryID        int       `json:"category_id"`
+	Stock             int       `json:"stock"`
+	LowStockThreshold int       `json:"low_stock_threshold"`
+	PreviousLevel     string    `json:"previous_level"`
+	EventTime         time.Time `json:"event_time"`
+}
+
+// StockLevel — 재고와 임계치로 수준 판정.
+func StockLevel(stock, lowStockThreshold int) string {
+	switch {
+	case stock <= 0:
+		return StockLevelOut
+	case stock <= lowStockThreshold:
+		return StockLevelLow
+	default:
+		return StockLevelOK
+	}
+}
+
+// StockShortfall — 재고가 모자란 상품 한 건 (같은 상품 라인은 합산한 요청 수량).
+type StockShortfall struct {
+	ProductID int64 `json:"product_id"`
+	Requested int   `json:"requested"`
+	Available int   `json:"available"`
+}
+
+// InsufficientStockError — 주문에서 재고가 모자란 모든 상품. errors.Is(err, ErrInsufficientStock)로 판별.
+type InsufficientStockError struct {
+	Shortfalls []StockShortfall
+}
+
+func (e *InsufficientStockError) Error() string {
+	parts := make([]string, 0, len(e.Shortfalls))
+	for _, s := range e.Shortfalls {
+		parts = append(parts, fmt.Sprintf("product %d (available=%d, requested=%d)", s.ProductID, s.Available, s.Requested))
+	}
+	return fmt.Sprintf("%s: %s", ErrInsufficientStock, strings.Join(parts, ", "))
+}
+
+func (e *InsufficientStockError) Is(target error) bool {
+	return target == ErrInsufficientStock
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	dlqhandler "productfc/cmd/dlq/handler"
 	"productfc/cmd/product/handler"
 	"productfc/cmd/product/resource"
 	"productfc/config"
 	"productfc/middleware"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,15 +18,21 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRoutes(router *gin.Engine, productHandler *handler.ProductHandler) {
+func SetupRoutes(router *gin.Engine, productHandler *handler.ProductHandler, dlqHandler *dlqhandler.DLQHandler) {
 	router.Use(middleware.RequestLogger())
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	router.GET("/ping", productHandler.Ping())
 	router.GET("/health", func(c *gin.Context) {
+		status := "healthy"
+		degraded, cacheStatus := resource.CacheHealth(c.Request.Context())
+		if degraded {
+			status = "degraded"
+		}
 		c.JSON(200, gin.H{
-			"status":  "healthy",
+			"status":  status,
 			"service": "productfc",
+			"cache":   cacheStatus,
 		})
 	})
 
@@ -52,19 +60,27 @@ func SetupRoutes(router *gin.Engine, productHandler *handler.ProductHandler) {
 		snap := resource.KafkaMonitor.Snapshot()
 		var consumed, dlq int64
 		for k, v := range snap {
-			switch k {
-			case "stock_updated_ok", "stock_rollback_ok", "stock_updated_duplicate_skipped", "stock_rollback_duplicate_skipped":
-				consumed += v
-			case "stock_updated_dlq", "stock_rollback_dlq":
+			consumed += v
+			if strings.HasSuffix(k, "_dlq") {
 				dlq += v
 			}
 		}
+		produced := resource.KafkaMonitor.ProducedSnapshot()
+		var producedOK int64
+		for k, v := range produced {
+			if strings.HasSuffix(k, "_ok") {
+				producedOK += v
+			}
+		}
 		c.JSON(http.StatusOK, gin.H{
-			"service":             "productfc",
-			"messages_produced":   0,
-			"messages_consumed":   consumed,
-			"dlq_count":           dlq,
-			"consumer_stats":      snap,
+			"service":           "productfc",
+			"messages_produced": producedOK,
+			"messages_consumed": consumed,
+			"dlq_count":         dlq,
+			"consumer_stats":    snap,
+			"producer_stats":    produced,
+			"consumer_lag":      resource.KafkaMonitor.LagSnapshot(),
+			"consumers":         resource.KafkaMonitor.ConsumerStates(),
 		})
 	})
 
@@ -116,5 +132,23 @@ func SetupRoutes(router *gin.Engine, productHandler *handler.ProductHandler) {
 		private.POST("/v1/product-categories", productHandler.CreateNewProductCategory)
 		private.PUT("/v1/product-categories/:id", productHandler.EditProductCategory)
 		private.DELETE("/v1/product-categories/:id", productHandler.DeleteProductCategory)
+
+		private.PUT("/v1/products/:id/stock-threshold", productHandler.SetProductStockThreshold)
+		private.PUT("/v1/product-categories/:id/stock-threshold", productHandler.SetCategoryStockThreshold)
+		private.GET("/v1/products/:id/backorder", productHandler.GetBackorderSetting)
+		private.PUT("/v1/products/:id/backorder", productHandler.SetBackorderSetting)
+		private.POST("/v1/products/:id/stock-adjustments", productHandler.AdjustProductStock)
+		private.POST("/v1/products/stocktake", productHandler.ApplyStocktake)
+
+		private.POST("/v1/purchase-orders", productHandler.CreatePurchaseOrder)
+		private.GET("/v1/purchase-orders/:id", productHandler.GetPurchaseOrder)
+		private.POST("/v1/purchase-orders/:id/receipts", productHandler.ReceivePurchaseOrder)
+		private.POST("/v1/purchase-orders/:id/cancel", productHandler.CancelPurchaseOrder)
+
+		private.GET("/v1/admin/dlq", dlqHandler.SearchMessages)
+		private.GET("/v1/admin/dlq/stats", dlqHandler.GetStats)
+		private.GET("/v1/admin/dlq/:id", dlqHandler.GetMessage)
+		private.POST("/v1/admin/dlq/:id/replay", dlqHandler.ReplayMessage)
+		private.POST("/v1/admin/dlq/:id/resolve", dlqHandler.ResolveMessage)
 	}
 }
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KafkaHeaderCarrier — kafka.Message 헤더용 TextMapCarrier (traceparent/tracestate/baggage).
+type KafkaHeaderCarrier struct {
+	Headers *[]kafka.Header
+}
+
+var _ propagation.TextMapCarrier = KafkaHeaderCarrier{}
+
+func (c KafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set — 같은 키가 있으면 교체 (재시도/DLQ 재발행 시 이전 트레이스 헤더를 덮어씀).
+func (c KafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c.Headers {
+		if h.Key == key {
+			(*c.Headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.Headers = append(*c.Headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c KafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.Headers))
+	for _, h := range *c.Headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// ExtractKafka — 수신 메시지 헤더의 트레이스 컨텍스트를 ctx에 복원 (consumer span의 부모).
+func ExtractKafka(ctx context.Context, msg kafka.Message) context.Context {
+	headers := msg.Headers
+	return otel.GetTextMapPropagator().Extract(ctx, KafkaHeaderCarrier{Headers: &headers})
+}
+
+// StartProducerSpan — 발행 span을 시작하고 그 컨텍스트를 msg 헤더에 주입.
+// 호출자는 WriteMessages 결과를 EndProducerSpan으로 기록.
+// topic은 writer에 토픽이 고정된 경우(msg.Topic 비어 있음)를 위해 따로 받음.
+func StartProducerSpan(ctx context.Context, topic string, msg *kafka.Message) (context.Context, trace.Span) {
+	ctx, span := StartSpan(ctx, "kafka.produce."+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingMessagePayloadSizeBytes(len(msg.Value)),
+		),
+	)
+	if len(msg.Key) > 0 {
+		span.SetAttributes(semconv.MessagingKafkaMessageKey(string(msg.Key)))
+	}
+	otel.GetTextMapPropagator().Inject(ctx, KafkaHeaderCarrier{Headers: &msg.Headers})
+	return ctx, span
+}
+
+// EndProducerSpan — 발행 에러를 기록하고 span 종료.
+func EndProducerSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}